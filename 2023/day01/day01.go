@@ -0,0 +1,92 @@
+package day01
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"bta/aoc23/puzzle"
+)
+
+var (
+	numbersAsWord = []string{
+		"zero",
+		"one",
+		"two",
+		"three",
+		"four",
+		"five",
+		"six",
+		"seven",
+		"eight",
+		"nine",
+	}
+)
+
+//go:embed instructions.txt
+var files embed.FS
+
+func init() {
+	puzzle.Register(puzzle.Day{
+		Year:   2023,
+		Number: 1,
+		Title:  "Trebuchet?!",
+		Input:  "calibration_input.txt",
+		Files:  files,
+		Parts: [2]puzzle.Part{
+			{
+				Stream:   func(input io.Reader, _ puzzle.Options) (any, error) { return sumCoordinates(input, false) },
+				Examples: []puzzle.Example{{Block: 0, Answer: "142"}},
+			},
+			// Parse coordinates using also numbers written in letters (one, two, three...)
+			{
+				Stream:   func(input io.Reader, _ puzzle.Options) (any, error) { return sumCoordinates(input, true) },
+				Examples: []puzzle.Example{{Block: 1, Answer: "281"}},
+			},
+		},
+	})
+}
+
+func identifyLinePrefix(line string, enableNumbersAsLetters bool) (int, error) {
+	firstChar := line[0]
+
+	if '0' <= firstChar && firstChar <= '9' {
+		return int(firstChar - '0'), nil
+	}
+	if enableNumbersAsLetters {
+		for index, numberWord := range numbersAsWord {
+			if strings.HasPrefix(line, numberWord) {
+				return index, nil
+			}
+		}
+	}
+	return -1, fmt.Errorf("no number could be identified in the following string: %s", line)
+}
+
+func sumCoordinates(input io.Reader, enableNumbersAsLetters bool) (int, error) {
+	lines := puzzle.Lines(input)
+	total := 0
+
+	for lines.Next() {
+		line := lines.Text()
+		firstDigit, lastDigit := -1, -1
+
+		for index := range line {
+			number, _ := identifyLinePrefix(line[index:], enableNumbersAsLetters)
+
+			if number < 0 {
+				continue
+			}
+
+			if firstDigit < 0 {
+				firstDigit = number
+			}
+			lastDigit = number
+		}
+		total += firstDigit*10 + lastDigit
+	}
+	slog.Debug("processed coordinates", "day", 1, "total", total)
+	return total, lines.Err()
+}
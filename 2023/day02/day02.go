@@ -0,0 +1,115 @@
+package day02
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"bta/aoc23/puzzle"
+)
+
+var (
+	gameRegex = regexp.MustCompile(`^Game (?P<game>[0-9]+): (?P<line>.*)$`)
+)
+
+//go:embed instructions.txt
+var files embed.FS
+
+func init() {
+	limits := []puzzle.Option{
+		{Name: "reds-limit", Usage: "Sets reds ball amount limit", Default: 12},
+		{Name: "green-limit", Usage: "Sets green ball amount limit", Default: 13},
+		{Name: "blue-limit", Usage: "Sets blue ball amount limit", Default: 14},
+	}
+
+	puzzle.Register(puzzle.Day{
+		Year:    2023,
+		Number:  2,
+		Title:   "Cube Conundrum",
+		Input:   "calibration_input.txt",
+		Files:   files,
+		Options: limits,
+		Parts: [2]puzzle.Part{
+			{
+				Stream:   func(input io.Reader, opts puzzle.Options) (any, error) { return sumGames(input, opts, false) },
+				Examples: []puzzle.Example{{Block: 0, Answer: "8"}},
+			},
+			// Uses ball amount power instead of game number
+			{
+				Stream:   func(input io.Reader, opts puzzle.Options) (any, error) { return sumGames(input, opts, true) },
+				Examples: []puzzle.Example{{Block: 0, Answer: "2286"}},
+			},
+		},
+	})
+}
+
+func checkBallAmountIsValid(red, green, blue int, opts puzzle.Options) bool {
+	return red <= opts.Get("reds-limit") && green <= opts.Get("green-limit") && blue <= opts.Get("blue-limit")
+}
+
+// Largest amount of each colour shown in the turns
+func countBalls(turns []string) (int, int, int, error) {
+	redBalls, greenBalls, blueBalls := 0, 0, 0
+	ballBinding := map[string]*int{
+		"green": &greenBalls,
+		"red":   &redBalls,
+		"blue":  &blueBalls,
+	}
+
+	for _, turn := range turns {
+		details := strings.Split(turn, ",")
+
+		for _, ballDetails := range details {
+			ballDetails = strings.Trim(ballDetails, " ")
+			// parts[0] should be the ball amount, [1] should be the color
+			parts := strings.Split(ballDetails, " ")
+			if len(parts) != 2 {
+				return 0, 0, 0, fmt.Errorf("'%s' isn't a ball amount and a color", ballDetails)
+			}
+			parsedBallAmount, err := strconv.Atoi(parts[0])
+			if err != nil || parsedBallAmount < 0 {
+				return 0, 0, 0, fmt.Errorf("'%s' isn't a ball amount", parts[0])
+			}
+			savedBallAmount, found := ballBinding[parts[1]]
+			if !found {
+				return 0, 0, 0, fmt.Errorf("'%s' isn't a ball color, colors are red, green and blue", parts[1])
+			}
+
+			if *savedBallAmount < parsedBallAmount {
+				*savedBallAmount = parsedBallAmount
+			}
+		}
+	}
+	return redBalls, greenBalls, blueBalls, nil
+}
+
+func sumGames(input io.Reader, opts puzzle.Options, isSecondPart bool) (int, error) {
+	lines := puzzle.Lines(input)
+	sum := 0
+
+	for lines.Next() {
+		line := gameRegex.FindStringSubmatch(lines.Text())
+		if line == nil {
+			return 0, lines.Fail(fmt.Errorf("lines are written 'Game <number>: <turns>'"))
+		}
+		gameNb, err := strconv.Atoi(line[1])
+		if err != nil {
+			return 0, lines.Fail(err)
+		}
+		gameTurns := strings.Split(line[2], ";")
+		redBallAmount, greenBallAmount, blueBallAmount, err := countBalls(gameTurns)
+		if err != nil {
+			return 0, lines.Fail(err)
+		}
+
+		if !isSecondPart && checkBallAmountIsValid(redBallAmount, greenBallAmount, blueBallAmount, opts) {
+			sum += gameNb
+		} else if isSecondPart {
+			sum += redBallAmount * greenBallAmount * blueBallAmount
+		}
+	}
+	return sum, lines.Err()
+}
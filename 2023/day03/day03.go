@@ -1,12 +1,11 @@
-package main
+package day03
 
 import (
-	"flag"
-	"fmt"
-	"log"
-	"os"
+	"embed"
+	"log/slog"
 	"strconv"
-	"strings"
+
+	"bta/aoc23/puzzle"
 )
 
 type Position struct {
@@ -20,20 +19,35 @@ type EngineNumber struct {
 	marked   bool
 }
 
-const (
-	inputFile = "./calibration_input.txt"
-)
-
-var (
-	shouldFindGearRatios bool
-
+type Schematic struct {
 	numbers []EngineNumber
 	symbols []Position
-)
+}
+
+//go:embed instructions.txt
+var files embed.FS
 
 func init() {
-	flag.BoolVar(&shouldFindGearRatios, "find-gear-ratios", false, "Finds gear ratio instead of every engine numbers")
-	flag.Parse()
+	puzzle.Register(puzzle.Day{
+		Year:    2023,
+		Number:  3,
+		Title:   "Gear Ratios",
+		Input:   "calibration_input.txt",
+		Files:   files,
+		Grid:    true,
+		Inspect: puzzle.GridFacts,
+		Parts: [2]puzzle.Part{
+			{
+				Solve:    func(input []byte, _ puzzle.Options) (any, error) { return solve(input, false) },
+				Examples: []puzzle.Example{{Block: 0, Answer: "4361"}},
+			},
+			// Finds gear ratio instead of every engine numbers
+			{
+				Solve:    func(input []byte, _ puzzle.Options) (any, error) { return solve(input, true) },
+				Examples: []puzzle.Example{{Block: 0, Answer: "467835"}},
+			},
+		},
+	})
 }
 
 func (symbol Position) findAdjacent(ratios []EngineNumber) []*EngineNumber {
@@ -81,12 +95,12 @@ func cutString(base string, isValid func(rune) bool) string {
 	return base
 }
 
-func parseDigit(slice string, x, y int) int {
+func (s *Schematic) parseDigit(slice string, x, y int) int {
 	numberString := cutString(slice[x:], isDigit)
 	numberLength := len(numberString)
 
 	if number, err := strconv.Atoi(numberString); err == nil {
-		numbers = append(numbers, EngineNumber{
+		s.numbers = append(s.numbers, EngineNumber{
 			value: number,
 			position: Position{
 				x: x,
@@ -96,12 +110,12 @@ func parseDigit(slice string, x, y int) int {
 		})
 		return numberLength - 1
 	} else {
-		fmt.Printf("error during number parsing: %s\n", err)
+		slog.Warn("number skipped", "day", 3, "x", x, "y", y, "err", err)
 	}
 	return 0
 }
 
-func parseLine(line string, lineIndex int) {
+func (s *Schematic) parseLine(line string, lineIndex int) {
 	slice := line
 
 	for i := 0; i < len(slice); i++ {
@@ -111,9 +125,9 @@ func parseLine(line string, lineIndex int) {
 			continue
 		}
 		if isDigit(char) {
-			i += parseDigit(slice, i, lineIndex)
+			i += s.parseDigit(slice, i, lineIndex)
 		} else if isEngineSymbol(char) {
-			symbols = append(symbols, Position{
+			s.symbols = append(s.symbols, Position{
 				x: i,
 				y: lineIndex,
 			})
@@ -121,34 +135,34 @@ func parseLine(line string, lineIndex int) {
 	}
 }
 
-func main() {
-	file, err := os.ReadFile(inputFile)
+func solve(input []byte, shouldFindGearRatios bool) (int, error) {
+	// Symbols are any character but digits and '.'
+	fileLines, err := puzzle.ParseGrid(input, "")
 	if err != nil {
-		log.Fatalln("couldn't open input file\n", err)
+		return 0, err
 	}
-	fileLines := strings.Split(string(file[:]), "\n")
+	schematic := Schematic{}
 
 	for rowIndex, row := range fileLines {
-		parseLine(row, rowIndex)
+		schematic.parseLine(row, rowIndex)
 	}
 	sum := 0
-	for _, pos := range symbols {
+	for _, pos := range schematic.symbols {
 		if !shouldFindGearRatios {
-			markAdjacents(numbers, pos)
+			markAdjacents(schematic.numbers, pos)
 		} else if fileLines[pos.y][pos.x] == '*' {
-			if adjacents := pos.findAdjacent(numbers); len(adjacents) == 2 {
+			if adjacents := pos.findAdjacent(schematic.numbers); len(adjacents) == 2 {
 				sum += adjacents[0].value * adjacents[1].value
 			}
 		}
 	}
 
 	if !shouldFindGearRatios {
-		for _, v := range numbers {
+		for _, v := range schematic.numbers {
 			if v.marked {
 				sum += v.value
 			}
 		}
 	}
-
-	println("Result :", sum)
+	return sum, nil
 }
@@ -0,0 +1,118 @@
+package day04
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"bta/aoc23/puzzle"
+)
+
+var (
+	cardRegex = regexp.MustCompile(`(?m)Card\s+([0-9]+): ([0-9\s]+) \| ([0-9\s]+)$`)
+)
+
+//go:embed instructions.txt
+var files embed.FS
+
+func init() {
+	puzzle.Register(puzzle.Day{
+		Year:   2023,
+		Number: 4,
+		Title:  "Scratchcards",
+		Input:  "input.txt",
+		Files:  files,
+		Parts: [2]puzzle.Part{
+			{
+				Stream:   func(input io.Reader, _ puzzle.Options) (any, error) { return solve(input, false) },
+				Examples: []puzzle.Example{{Block: 0, Answer: "13"}},
+			},
+			// Every match on your card gives you an extra copy of the n next cards (where n is the amount of matches for your card)
+			{
+				Stream:   func(input io.Reader, _ puzzle.Options) (any, error) { return solve(input, true) },
+				Examples: []puzzle.Example{{Block: 0, Answer: "30"}},
+			},
+		},
+	})
+}
+
+func evaluateCardPoints(nbOfMatches int) int {
+	if nbOfMatches <= 0 {
+		return 0
+	}
+	return int(math.Pow(float64(2), float64(nbOfMatches-1)))
+}
+
+func parseCard(line string) (int, int, error) {
+	var winningNumbers []string
+	var playedNumbers []string
+	match := 0
+
+	matches := cardRegex.FindAllStringSubmatch(line, -1)
+	if len(matches) == 0 {
+		return 0, 0, fmt.Errorf("error: line isn't a card: %s", line)
+	}
+	results := matches[0]
+	cardNumber, conversionError := strconv.Atoi(results[1])
+	winningNumbers = strings.Split(results[2], " ")
+	playedNumbers = strings.Split(results[3], " ")
+
+	if conversionError != nil {
+		return 0, 0, fmt.Errorf("error: card number (%s) couldn't be parsed:\n%v", results[1], conversionError)
+	}
+	for _, winningRef := range winningNumbers {
+		if winningRef == "" {
+			continue
+		}
+		for _, number := range playedNumbers {
+			if strings.Trim(number, " ") == strings.Trim(winningRef, " ") {
+				match++
+			}
+		}
+	}
+	return cardNumber, match, nil
+}
+
+func solve(input io.Reader, useCardCopyRule bool) (int, error) {
+	lines := puzzle.Lines(input)
+	// Copies won for the next cards, pendingCopies[0] is for the next one
+	pendingCopies := make([]int, 0)
+	pointTotal := 0
+	cardTotal := 0
+
+	for lines.Next() {
+		_, matchAmount, err := parseCard(lines.Text())
+		if err != nil {
+			return 0, lines.Fail(err)
+		}
+		copies := 1
+		if len(pendingCopies) > 0 {
+			copies += pendingCopies[0]
+			pendingCopies = pendingCopies[1:]
+		}
+
+		pointTotal += evaluateCardPoints(matchAmount) * copies
+		if useCardCopyRule {
+			for i := 0; i < matchAmount; i++ {
+				if i < len(pendingCopies) {
+					pendingCopies[i] += copies
+				} else {
+					pendingCopies = append(pendingCopies, copies)
+				}
+			}
+		}
+		cardTotal += copies
+	}
+	if err := lines.Err(); err != nil {
+		return 0, err
+	}
+
+	if useCardCopyRule {
+		return cardTotal, nil
+	}
+	return pointTotal, nil
+}
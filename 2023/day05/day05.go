@@ -0,0 +1,469 @@
+package day05
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"bta/aoc23/puzzle"
+	"bta/aoc23/workpool"
+)
+
+//go:embed instructions.txt
+var files embed.FS
+
+func init() {
+	puzzle.Register(puzzle.Day{
+		Year:    2023,
+		Number:  5,
+		Title:   "If You Give A Seed A Fertilizer",
+		Input:   "input.txt",
+		Files:   files,
+		Explore: explore,
+		Inspect: inspect,
+		Parts: [2]puzzle.Part{
+			{
+				Solve:    func(input []byte, _ puzzle.Options) (any, error) { return solve(input, false) },
+				Examples: []puzzle.Example{{Block: 0, Answer: "35"}},
+			},
+			// seeds are ranges instead of simple seeds
+			{
+				Solve:    func(input []byte, _ puzzle.Options) (any, error) { return solve(input, true) },
+				Examples: []puzzle.Example{{Block: 0, Answer: "46"}},
+			},
+		},
+	})
+}
+
+type Range struct {
+	// Destination range start
+	start int
+	// Ranges length
+	length int
+}
+
+type ByStartIndex []Range
+
+func (ranges ByStartIndex) Len() int           { return len(ranges) }
+func (ranges ByStartIndex) Swap(i, j int)      { ranges[i], ranges[j] = ranges[j], ranges[i] }
+func (ranges ByStartIndex) Less(i, j int) bool { return ranges[i].start < ranges[j].start }
+
+type Mapper struct {
+	source, destination Range
+}
+
+type PuzzleMap struct {
+	sourceId      string
+	destinationId string
+	mappers       []Mapper
+}
+
+type MapChain struct {
+	startId string
+	maps    []PuzzleMap
+}
+
+func (chain *MapChain) setBlockPosition(blockId string, position int) error {
+	var blockIndex int
+
+	if blockIndex = slices.IndexFunc(chain.maps, func(m PuzzleMap) bool {
+		return m.sourceId == chain.startId
+	}); blockIndex < 0 {
+		return fmt.Errorf("couldn't sort chain because it is missing its starting block (id: %v)", chain.startId)
+	}
+	chain.maps[0], chain.maps[blockIndex] = chain.maps[blockIndex], chain.maps[0]
+	return nil
+}
+
+func (chain *MapChain) Sort() error {
+	chain.setBlockPosition(chain.startId, 0)
+	for index := range chain.maps {
+		if err := chain.setBlockPosition(chain.maps[index].destinationId, index+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (chain MapChain) Evaluate(id int) int {
+	for _, block := range chain.maps {
+		newId := block.GetNumber(id)
+		id = newId
+	}
+	return id
+}
+
+func (chain MapChain) ReverseEvaluate(id int) int {
+	for i := len(chain.maps) - 1; i >= 0; i-- {
+		block := chain.maps[i]
+		newId := block.GetRoot(id)
+		id = newId
+	}
+	return id
+}
+
+func (r Range) IsInRange(number int) bool {
+	return r.start <= number && number < r.start+r.length
+}
+
+func (r Mapper) GetNumber(number int) int {
+	if r.source.IsInRange(number) {
+		return number + r.destination.start - r.source.start
+	}
+	return number
+}
+
+func (r Mapper) GetRoot(number int) int {
+	if r.destination.IsInRange(number) {
+		return number - (r.destination.start - r.source.start)
+	}
+	return number
+}
+
+func mapperFromString(input string) (Mapper, error) {
+	inputs := strings.Split(input, " ")
+	errors := make([]error, 3)
+	var destinationRangeStart, sourceRangeStart, rangeLength int
+
+	if len(inputs) != 3 {
+		return Mapper{}, fmt.Errorf("range parsing error: found more than 3 numbers")
+	}
+
+	destinationRangeStart, errors[0] = strconv.Atoi(inputs[0])
+	sourceRangeStart, errors[1] = strconv.Atoi(inputs[1])
+	rangeLength, errors[2] = strconv.Atoi(inputs[2])
+
+	for _, err := range errors {
+		if err != nil {
+			return Mapper{}, fmt.Errorf("range parsing error: %v", err)
+		}
+	}
+
+	return Mapper{
+		destination: Range{start: destinationRangeStart, length: rangeLength},
+		source:      Range{start: sourceRangeStart, length: rangeLength},
+	}, nil
+}
+
+// Returned once every map of the almanac is parsed
+var errNoMapLeft = fmt.Errorf("cannot parse map: no map header left")
+
+func puzzleMapFromLines(input *[]string) (PuzzleMap, error) {
+	var reg = regexp.MustCompile(`(?m)([[:alpha:]]+)-to-([[:alpha:]]+).*$`)
+
+	for len(*input) > 0 && !reg.MatchString((*input)[0]) {
+		*input = (*input)[1:]
+	}
+	if len(*input) <= 0 {
+		return PuzzleMap{}, errNoMapLeft
+	}
+
+	regResults := reg.FindStringSubmatch((*input)[0])
+	*input = (*input)[1:]
+	if len(regResults) != 3 {
+		return PuzzleMap{}, fmt.Errorf("map parsing error: couldn't identify map resource ids")
+	}
+
+	mappers := make([]Mapper, 0)
+
+	for ; len(*input) > 0 && (*input)[0] != ""; *input = (*input)[1:] {
+		if m, err := mapperFromString((*input)[0]); err == nil {
+			mappers = append(mappers, m)
+		} else {
+			return PuzzleMap{}, err
+		}
+	}
+
+	return PuzzleMap{
+		sourceId:      regResults[1],
+		destinationId: regResults[2],
+		mappers:       mappers,
+	}, nil
+}
+
+func (m PuzzleMap) GetNumber(number int) int {
+	for _, r := range m.mappers {
+		if mapped := r.GetNumber(number); r.source.IsInRange(number) {
+			return mapped
+		}
+	}
+	return number
+}
+
+func (m PuzzleMap) GetRoot(number int) int {
+	for _, r := range m.mappers {
+		if mapped := r.GetRoot(number); r.destination.IsInRange(number) {
+			return mapped
+		}
+	}
+	return number
+}
+
+func parseSeeds(line string) ([]int, error) {
+	reg := regexp.MustCompile(`seeds: ([[:digit:] ]+)$`)
+	regResults := reg.FindStringSubmatch(line)
+	var seeds []int
+
+	if len(regResults) != 2 {
+		return seeds, fmt.Errorf("couldn't find seeds line")
+	}
+	for _, parsedNumber := range strings.Split(regResults[1], " ") {
+		if seedNumber, parsingError := strconv.Atoi(parsedNumber); parsingError != nil {
+			return seeds, fmt.Errorf("couldn't parse seed number\n%v", parsingError)
+		} else {
+			seeds = append(seeds, seedNumber)
+		}
+	}
+
+	return seeds, nil
+}
+
+func mapSeedsToRangeList(seeds []int) []Range {
+	ranges := make([]Range, len(seeds)/2)
+
+	for i := 0; i < len(seeds); i += 2 {
+		ranges[i/2] = Range{
+			start:  seeds[i],
+			length: seeds[i+1],
+		}
+	}
+	return ranges
+}
+
+func parseAlmanac(input []byte) ([]int, MapChain, error) {
+	fileLines := strings.Split(string(input), "\n")
+	lineCount := len(fileLines)
+	seeds, seedParsingErr := parseSeeds(fileLines[0])
+	mapPuzzles := make([]PuzzleMap, 0)
+
+	if seedParsingErr != nil {
+		return nil, MapChain{}, &puzzle.ParseError{Line: 1, Text: fileLines[0], Err: seedParsingErr}
+	}
+
+	for {
+		p, err := puzzleMapFromLines(&fileLines)
+		if err == errNoMapLeft {
+			break
+		}
+		if err != nil {
+			// The faulty line is the first one left
+			return nil, MapChain{}, &puzzle.ParseError{Line: lineCount - len(fileLines) + 1, Text: fileLines[0], Err: err}
+		}
+		mapPuzzles = append(mapPuzzles, p)
+	}
+
+	chain := MapChain{
+		startId: "seed",
+		maps:    mapPuzzles,
+	}
+
+	if err := chain.Sort(); err != nil {
+		return nil, MapChain{}, err
+	}
+	return seeds, chain, nil
+}
+
+func solve(input []byte, useSeedRanges bool) (int, error) {
+	seeds, chain, err := parseAlmanac(input)
+	if err != nil {
+		return 0, err
+	}
+
+	var rangeList []Range
+	if useSeedRanges {
+		rangeList = mapSeedsToRangeList(seeds)
+	} else {
+		rangeList = make([]Range, 0, len(seeds))
+		for _, seed := range seeds {
+			rangeList = append(rangeList, Range{
+				start:  seed,
+				length: 1,
+			})
+		}
+	}
+
+	// Seed ranges go down the chain independently, each worker takes its own
+	closestLocation := -1
+	for _, location := range workpool.Map(rangeList, chain.LowestLocation) {
+		if location >= 0 && (location < closestLocation || closestLocation < 0) {
+			closestLocation = location
+		}
+	}
+	if closestLocation < 0 {
+		return 0, fmt.Errorf("the almanac has no seed")
+	}
+	return closestLocation, nil
+}
+
+// Ranges the numbers of r are converted to. r is cut at the bounds of the
+// mappers, so that every number of a piece is converted by the same mappers.
+func (m PuzzleMap) MapRange(r Range) []Range {
+	cuts := []int{r.start, r.start + r.length}
+
+	for _, mapper := range m.mappers {
+		for _, bound := range []int{mapper.source.start, mapper.source.start + mapper.source.length} {
+			if r.start < bound && bound < r.start+r.length {
+				cuts = append(cuts, bound)
+			}
+		}
+	}
+	slices.Sort(cuts)
+	cuts = slices.Compact(cuts)
+
+	mapped := make([]Range, 0, len(cuts)-1)
+	for index := 0; index+1 < len(cuts); index++ {
+		mapped = append(mapped, Range{
+			start:  m.GetNumber(cuts[index]),
+			length: cuts[index+1] - cuts[index],
+		})
+	}
+	return mapped
+}
+
+// Lowest location of the seeds of r, -1 when r is empty. The range is split
+// as it goes down the chain, the seeds are never walked one by one.
+func (chain MapChain) LowestLocation(r Range) int {
+	ranges := []Range{r}
+
+	for _, block := range chain.maps {
+		mapped := make([]Range, 0, len(ranges))
+		for _, r := range ranges {
+			mapped = append(mapped, block.MapRange(r)...)
+		}
+		ranges = mapped
+	}
+	lowest := -1
+	for _, r := range ranges {
+		if r.start < lowest || lowest < 0 {
+			lowest = r.start
+		}
+	}
+	slog.Debug("seed range located", "day", 5, "start", r.start, "length", r.length, "pieces", len(ranges), "location", lowest)
+	return lowest
+}
+
+// Index of the block converting the category, len(chain.maps) for the last destination
+func (chain MapChain) categoryIndex(category string, fromDestination bool) int {
+	for index, block := range chain.maps {
+		if !fromDestination && block.sourceId == category {
+			return index
+		}
+		if fromDestination && block.destinationId == category {
+			return index + 1
+		}
+	}
+	return -1
+}
+
+func explore(input []byte, _ puzzle.Options) ([]puzzle.Command, error) {
+	seeds, chain, err := parseAlmanac(input)
+	if err != nil {
+		return nil, err
+	}
+	numberArg := func(args []string) (string, int, error) {
+		if len(args) != 2 {
+			return "", 0, fmt.Errorf("expected a category and a number")
+		}
+		number, err := strconv.Atoi(args[1])
+		return args[0], number, err
+	}
+
+	return []puzzle.Command{
+		{
+			Name:  "seeds",
+			Usage: "list the seeds of the almanac",
+			Run: func(_ []string, out io.Writer) error {
+				_, err := fmt.Fprintln(out, seeds)
+				return err
+			},
+		},
+		{
+			Name:  "maps",
+			Usage: "list the maps of the chain, in evaluation order",
+			Run: func(_ []string, out io.Writer) error {
+				for index, block := range chain.maps {
+					fmt.Fprintf(out, "%d: %s-to-%s (%d ranges)\n", index, block.sourceId, block.destinationId, len(block.mappers))
+				}
+				return nil
+			},
+		},
+		{
+			Name:  "eval",
+			Args:  "<category> <number>",
+			Usage: "follow a number from its category down to the location",
+			Run: func(args []string, out io.Writer) error {
+				category, number, err := numberArg(args)
+				if err != nil {
+					return err
+				}
+				start := chain.categoryIndex(category, false)
+				if start < 0 {
+					return fmt.Errorf("no map converts '%s'", category)
+				}
+				fmt.Fprintf(out, "%s %d", category, number)
+				for _, block := range chain.maps[start:] {
+					number = block.GetNumber(number)
+					fmt.Fprintf(out, " -> %s %d", block.destinationId, number)
+				}
+				fmt.Fprintln(out)
+				return nil
+			},
+		},
+		{
+			Name:  "reverse",
+			Args:  "<category> <number>",
+			Usage: "follow a number from its category back up to the seed",
+			Run: func(args []string, out io.Writer) error {
+				category, number, err := numberArg(args)
+				if err != nil {
+					return err
+				}
+				end := chain.categoryIndex(category, true)
+				if end < 0 {
+					return fmt.Errorf("no map produces '%s'", category)
+				}
+				fmt.Fprintf(out, "%s %d", category, number)
+				for i := end - 1; i >= 0; i-- {
+					number = chain.maps[i].GetRoot(number)
+					fmt.Fprintf(out, " -> %s %d", chain.maps[i].sourceId, number)
+				}
+				fmt.Fprintln(out)
+				return nil
+			},
+		},
+	}, nil
+}
+
+// The seed ranges are what part 2 splits down the chain, one worker each
+func inspect(input []byte, _ puzzle.Options) ([]puzzle.Fact, error) {
+	seeds, chain, err := parseAlmanac(input)
+	if err != nil {
+		return nil, err
+	}
+	mappers := 0
+	for _, block := range chain.maps {
+		mappers += len(block.mappers)
+	}
+	seedRanges := mapSeedsToRangeList(seeds)
+	largest, total := Range{}, 0
+	for _, seedRange := range seedRanges {
+		if seedRange.length > largest.length {
+			largest = seedRange
+		}
+		total += seedRange.length
+	}
+
+	return []puzzle.Fact{
+		{Name: "seeds", Value: len(seeds)},
+		{Name: "map blocks", Value: len(chain.maps)},
+		{Name: "map ranges", Value: mappers},
+		{Name: "seed ranges", Value: len(seedRanges)},
+		{Name: "largest seed range", Value: fmt.Sprintf("%d seeds from %d", largest.length, largest.start)},
+		{Name: "seeds in ranges", Value: total},
+	}, nil
+}
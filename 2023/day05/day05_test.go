@@ -0,0 +1,34 @@
+package day05
+
+import (
+	"testing"
+
+	"bta/aoc23/puzzle"
+)
+
+// Splitting the ranges against evaluating every seed of them, over the
+// example almanac whose numbers are below 100
+func TestLowestLocation(t *testing.T) {
+	instructions, err := files.ReadFile("instructions.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, chain, err := parseAlmanac([]byte(puzzle.ExtractExamples(instructions)[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for start := 0; start < 110; start++ {
+		for length := 1; length < 30; length++ {
+			expected := -1
+			for seed := start; seed < start+length; seed++ {
+				if location := chain.Evaluate(seed); location < expected || expected < 0 {
+					expected = location
+				}
+			}
+			if lowest := chain.LowestLocation(Range{start: start, length: length}); lowest != expected {
+				t.Fatalf("%d seeds from %d: lowest location %d, expected %d", length, start, lowest, expected)
+			}
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package day06
+
+import (
+	"embed"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"bta/aoc23/arith"
+	"bta/aoc23/puzzle"
+)
+
+//go:embed instructions.txt
+var files embed.FS
+
+func init() {
+	puzzle.Register(puzzle.Day{
+		Year:    2023,
+		Number:  6,
+		Title:   "Wait For It",
+		Input:   "input.txt",
+		Files:   files,
+		Options: []puzzle.Option{puzzle.BigInt},
+		Parts: [2]puzzle.Part{
+			{
+				Solve: func(input []byte, opts puzzle.Options) (any, error) {
+					return solve(input, false, opts.Get("bigint") == 1)
+				},
+				Examples: []puzzle.Example{{Block: 0, Answer: "288"}},
+			},
+			// there is simply one race with all the concatenated numbers
+			{
+				Solve: func(input []byte, opts puzzle.Options) (any, error) {
+					return solve(input, true, opts.Get("bigint") == 1)
+				},
+				Examples: []puzzle.Example{{Block: 0, Answer: "71503"}},
+			},
+		},
+	})
+}
+
+type RaceRecord struct {
+	time, distance int
+}
+
+// Equation is as follows f(x)=-x²*tx-d (a: -1, b: t, c: -d)
+// t is race.time
+// d is race.distance
+func solveRace(race RaceRecord) (float64, float64, error) {
+	time := float64(race.time)
+	distance := float64(race.distance)
+	delta := time*time - 4*distance // ∆ = b^2-4ac
+
+	if delta >= 0 {
+		x0, x1 := (-time-math.Sqrt(delta))/-2, (-time+math.Sqrt(delta))/-2
+
+		if x0 < x1 {
+			if x0 == math.Ceil(x0) {
+				x0++
+			}
+			if x1 == math.Floor(x1) {
+				x1--
+			}
+			return math.Ceil(x0), math.Floor(x1), nil
+		} else {
+			if x0 == math.Floor(x0) {
+				x0--
+			}
+			if x1 == math.Ceil(x1) {
+				x1++
+			}
+			return math.Ceil(x1), math.Floor(x0), nil
+		}
+	}
+	return 0, 0, fmt.Errorf("race has no real solutions")
+}
+
+func parseFile(input []byte, mergeRacesInput bool) ([]RaceRecord, error) {
+	fileLines := strings.Split(string(input), "\n")
+	if len(fileLines) < 2 {
+		return nil, &puzzle.ParseError{Line: len(fileLines) + 1, Err: fmt.Errorf("expected a time and a distance line")}
+	}
+	reg := regexp.MustCompile(`[[:digit:]]+`)
+	timeResults := reg.FindAllString(fileLines[0], -1)
+	distanceResults := reg.FindAllString(fileLines[1], -1)
+
+	if len(timeResults) == 0 {
+		return nil, &puzzle.ParseError{Line: 1, Text: fileLines[0], Err: fmt.Errorf("no race time")}
+	}
+	if len(distanceResults) != len(timeResults) {
+		return nil, &puzzle.ParseError{Line: 2, Text: fileLines[1], Err: fmt.Errorf("%d distances for %d race times", len(distanceResults), len(timeResults))}
+	}
+
+	if mergeRacesInput {
+		timeResults = []string{
+			strings.Join(timeResults, ""),
+		}
+		distanceResults = []string{
+			strings.Join(distanceResults, ""),
+		}
+	}
+
+	races := make([]RaceRecord, len(timeResults))
+
+	for index := range timeResults {
+		time, timeError := strconv.Atoi(timeResults[index])
+		if timeError != nil {
+			return nil, &puzzle.ParseError{Line: 1, Text: fileLines[0], Err: timeError}
+		}
+		distance, distanceError := strconv.Atoi(distanceResults[index])
+		if distanceError != nil {
+			return nil, &puzzle.ParseError{Line: 2, Text: fileLines[1], Err: distanceError}
+		}
+
+		races[index] = RaceRecord{
+			time:     time,
+			distance: distance,
+		}
+	}
+	return races, nil
+}
+
+// Floats hold integers exactly up to 2^53, ∆ = t²-4d stays below it for
+// races within these limits, bigger races are solved with math/big
+const (
+	maxFloatTime     = 1 << 25
+	maxFloatDistance = 1 << 50
+)
+
+// Amount of hold times beating the record, found from the integer square
+// root of ∆ and adjusted by hand: x beats it when x*(t-x) > d, and the
+// winners are symmetric around t/2
+func waysToWinBig(race RaceRecord) (*big.Int, error) {
+	time := big.NewInt(int64(race.time))
+	distance := big.NewInt(int64(race.distance))
+	delta := new(big.Int).Mul(time, time)
+	delta.Sub(delta, new(big.Int).Mul(big.NewInt(4), distance))
+
+	if delta.Sign() < 0 {
+		return nil, fmt.Errorf("race has no real solutions")
+	}
+	one := big.NewInt(1)
+	half := new(big.Int).Quo(time, big.NewInt(2))
+	beats := func(x *big.Int) bool {
+		travelled := new(big.Int).Sub(time, x)
+		return travelled.Mul(travelled, x).Cmp(distance) > 0
+	}
+
+	first := new(big.Int).Sub(time, delta.Sqrt(delta))
+	first.Quo(first, big.NewInt(2))
+	for !beats(first) && first.Cmp(half) <= 0 {
+		first.Add(first, one)
+	}
+	for first.Sign() > 0 && beats(new(big.Int).Sub(first, one)) {
+		first.Sub(first, one)
+	}
+	if !beats(first) {
+		return new(big.Int), nil
+	}
+	ways := new(big.Int).Sub(time, first)
+	return ways.Sub(ways, first).Add(ways, one), nil
+}
+
+func solve(input []byte, mergeRacesInput, useBigInt bool) (any, error) {
+	races, parsingError := parseFile(input, mergeRacesInput)
+
+	if parsingError != nil {
+		return 0, parsingError
+	}
+
+	// A few races only, their product is always computed exactly
+	product := big.NewInt(1)
+	for _, race := range races {
+		if useBigInt || race.time > maxFloatTime || race.distance > maxFloatDistance {
+			if ways, err := waysToWinBig(race); err == nil {
+				product.Mul(product, ways)
+			}
+			continue
+		}
+		raceRootLeft, raceRootRight, err := solveRace(race)
+
+		if err == nil {
+			ye := math.Abs(raceRootLeft - raceRootRight)
+			wat := int(ye) + 1
+			product.Mul(product, big.NewInt(int64(wat)))
+		}
+	}
+	return arith.Answer(product), nil
+}
@@ -0,0 +1,195 @@
+package day07
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"bta/aoc23/puzzle"
+)
+
+const (
+	LEGAL_CARDS_CLASSIC_RULE = "AKQJT98765432"
+	LEGAL_CARDS_JOKER_RULE   = "AKQT98765432J"
+)
+
+// Cards ordered by strength, and whether J are jokers
+type Rules struct {
+	LegalCards string
+	UseJokers  bool
+}
+
+var (
+	classicRules = Rules{LegalCards: LEGAL_CARDS_CLASSIC_RULE}
+	jokerRules   = Rules{LegalCards: LEGAL_CARDS_JOKER_RULE, UseJokers: true}
+)
+
+//go:embed instructions.txt
+var files embed.FS
+
+func init() {
+	puzzle.Register(puzzle.Day{
+		Year:   2023,
+		Number: 7,
+		Title:  "Camel Cards",
+		Input:  "input.txt",
+		Files:  files,
+		Parts: [2]puzzle.Part{
+			{
+				Stream:   func(input io.Reader, _ puzzle.Options) (any, error) { return solve(input, classicRules) },
+				Examples: []puzzle.Example{{Block: 0, Answer: "6440"}},
+			},
+			// Jacks become Jokers, see rules in instructions
+			{
+				Stream:   func(input io.Reader, _ puzzle.Options) (any, error) { return solve(input, jokerRules) },
+				Examples: []puzzle.Example{{Block: 0, Answer: "5905"}},
+			},
+		},
+	})
+}
+
+type Hand struct {
+	Cards string
+	Type  int
+	Bid   int
+}
+
+func identifyHandType(hand string, rules Rules) (int, error) {
+	cards := make(map[rune]int)
+
+	for _, b := range hand {
+		if !strings.ContainsRune(rules.LegalCards, b) {
+			return -1, fmt.Errorf("hand contains illegal cards")
+		}
+		prevValue := cards[b]
+		cards[b] = prevValue + 1
+	}
+
+	maxSameCard := 0
+	differentCardKind := 0
+	pairAmount := 0
+	jokerAmount := 0
+	for card, amount := range cards {
+		// Skip J because it shouldn't be identified as anything
+		if rules.UseJokers && card == 'J' {
+			jokerAmount += amount
+			continue
+		}
+		differentCardKind++
+		if amount == 2 {
+			pairAmount++
+		}
+		if amount > maxSameCard {
+			maxSameCard = amount
+		}
+	}
+
+	// You now have to take jokers in account as possibly helping the highest amount of same card
+	if rules.UseJokers {
+		maxSameCard += jokerAmount
+	}
+
+	switch {
+	// Quinte
+	case maxSameCard == 5:
+		return 0, nil
+	// Carré
+	case maxSameCard == 4:
+		return 1, nil
+	// Full
+	case differentCardKind == 2 && maxSameCard == 3 && (pairAmount == 1 || rules.UseJokers && jokerAmount >= 1 && pairAmount >= 1):
+		return 2, nil
+	// Brelan
+	case maxSameCard == 3:
+		return 3, nil
+	// 2 Paires
+	case pairAmount == 2:
+		return 4, nil
+	// Paire
+	case pairAmount == 1 || (rules.UseJokers && maxSameCard == 2 && jokerAmount >= 1):
+		return 5, nil
+	// Hauteur
+	default:
+		return 6, nil
+	}
+}
+
+func parseHand(source string, rules Rules) (Hand, error) {
+	parts := strings.Split(source, " ")
+
+	if len(parts) != 2 || len(parts[0]) != 5 {
+		return Hand{}, fmt.Errorf("parsing error, source must have 2 parts separated by a ' ' and left part should be a string of 5 characters representing the hand of the player")
+	}
+
+	bid, convError := strconv.Atoi(parts[1])
+	cardType, identifyError := identifyHandType(parts[0], rules)
+
+	if convError != nil {
+		return Hand{}, fmt.Errorf("parsing error on bid part, %v", convError)
+	}
+	if identifyError != nil {
+		return Hand{}, fmt.Errorf("parsing error on hand part, %v", identifyError)
+	}
+
+	return Hand{
+		Cards: parts[0],
+		Type:  cardType,
+		Bid:   bid,
+	}, nil
+}
+
+type ByHandPower struct {
+	Hands []Hand
+	Rules Rules
+}
+
+func (ranges ByHandPower) Len() int { return len(ranges.Hands) }
+func (ranges ByHandPower) Swap(i, j int) {
+	ranges.Hands[i], ranges.Hands[j] = ranges.Hands[j], ranges.Hands[i]
+}
+func (ranges ByHandPower) Less(i, j int) bool {
+	hands := ranges.Hands
+	if hands[i].Type != hands[j].Type {
+		return hands[i].Type > hands[j].Type
+	}
+	for index := range hands[i].Cards {
+		left := strings.IndexByte(ranges.Rules.LegalCards, hands[i].Cards[index])
+		right := strings.IndexByte(ranges.Rules.LegalCards, hands[j].Cards[index])
+
+		if left == right {
+			continue
+		}
+		return left > right
+	}
+	slog.Warn("hands are equal, it shouldn't happen", "day", 7, "cards", hands[i].Cards)
+	return false
+}
+
+// Hands have to be ranked together, only their parsing is streamed
+func solve(input io.Reader, rules Rules) (int, error) {
+	lines := puzzle.Lines(input)
+	hands := make([]Hand, 0)
+
+	for lines.Next() {
+		if hand, err := parseHand(lines.Text(), rules); err == nil {
+			hands = append(hands, hand)
+		} else {
+			return 0, lines.Fail(err)
+		}
+	}
+	if err := lines.Err(); err != nil {
+		return 0, err
+	}
+
+	sort.Sort(ByHandPower{Hands: hands, Rules: rules})
+
+	sum := 0
+	for index, hand := range hands {
+		sum += hand.Bid * (index + 1)
+	}
+	return sum, nil
+}
@@ -0,0 +1,60 @@
+package day07
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"bta/aoc23/proptest"
+)
+
+// Cards of a hand, drawn from a few kinds of cards so that pairs, fulls and
+// the like are as common as high cards
+type cards string
+
+func (cards) Generate(rand *rand.Rand, _ int) reflect.Value {
+	kinds := []byte(LEGAL_CARDS_CLASSIC_RULE)
+	rand.Shuffle(len(kinds), func(i, j int) { kinds[i], kinds[j] = kinds[j], kinds[i] })
+	kinds = kinds[:1+rand.Intn(5)]
+
+	hand := make([]byte, 5)
+	for index := range hand {
+		hand[index] = kinds[rand.Intn(len(kinds))]
+	}
+	return reflect.ValueOf(cards(hand))
+}
+
+func TestRankingProperties(t *testing.T) {
+	for _, rules := range []Rules{classicRules, jokerRules} {
+		proptest.Check(t, func(a, b, c cards) bool {
+			hands := ByHandPower{Rules: rules}
+			for _, source := range []cards{a, b, c} {
+				hand, err := parseHand(string(source)+" 1", rules)
+				if err != nil {
+					t.Log(err)
+					return false
+				}
+				hands.Hands = append(hands.Hands, hand)
+			}
+
+			// Equal hands are neither less nor greater, any other two are
+			// exactly one of them
+			for i := range hands.Hands {
+				for j := range hands.Hands {
+					if i == j || hands.Hands[i].Cards == hands.Hands[j].Cards {
+						continue
+					}
+					if hands.Less(i, j) == hands.Less(j, i) {
+						t.Logf("%v: %s and %s aren't ordered", rules, hands.Hands[i].Cards, hands.Hands[j].Cards)
+						return false
+					}
+				}
+			}
+			if hands.Less(0, 1) && hands.Less(1, 2) && !hands.Less(0, 2) {
+				t.Logf("%v: %s < %s < %s but not %s < %s", rules, a, b, c, a, c)
+				return false
+			}
+			return true
+		})
+	}
+}
@@ -0,0 +1,373 @@
+package day08
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"bta/aoc23/arith"
+	"bta/aoc23/puzzle"
+	"bta/aoc23/workpool"
+)
+
+//go:embed instructions.txt
+var files embed.FS
+
+func init() {
+	puzzle.Register(puzzle.Day{
+		Year:    2023,
+		Number:  8,
+		Title:   "Haunted Wasteland",
+		Input:   "input.txt",
+		Files:   files,
+		Options: []puzzle.Option{puzzle.BigInt},
+		Explore: explore,
+		Inspect: inspect,
+		Parts: [2]puzzle.Part{
+			{
+				Solve: func(input []byte, opts puzzle.Options) (any, error) {
+					return solve(input, false, opts.Get("bigint") == 1)
+				},
+				Examples: []puzzle.Example{
+					{Block: 0, Answer: "2"},
+					{Block: 1, Answer: "6"},
+				},
+			},
+			// Uses ghosts navigation rules
+			{
+				Solve: func(input []byte, opts puzzle.Options) (any, error) {
+					return solve(input, true, opts.Get("bigint") == 1)
+				},
+				Examples: []puzzle.Example{{Block: 2, Answer: "6"}},
+			},
+		},
+	})
+}
+
+type BTNode struct {
+	id          string
+	left, right string
+}
+
+type NodeGroup []*BTNode
+
+// Parsed input, along with the navigation rules it is walked with
+type Network struct {
+	instructions    string
+	nodes           map[string]BTNode
+	ghostNavigation bool
+}
+
+func (network *Network) walkLeft(node *BTNode) *BTNode {
+	leftNode, exists := network.nodes[node.left]
+
+	if exists {
+		return &leftNode
+	} else {
+		return nil
+	}
+}
+
+func (network *Network) walkRight(node *BTNode) *BTNode {
+	rightNode, exists := network.nodes[node.right]
+
+	if exists {
+		return &rightNode
+	} else {
+		return nil
+	}
+}
+
+func (network *Network) isFinalDestination(node *BTNode) bool {
+	if !network.ghostNavigation {
+		return node.id == "ZZZ"
+	} else {
+		return node.id[len(node.id)-1] == 'Z'
+	}
+}
+
+func parseNode(input string) (BTNode, error) {
+	var re = regexp.MustCompile(`(?m)([0-9A-Z]{3}) = \(([0-9A-Z]{3}), ([0-9A-Z]{3})\)$`)
+	regexResult := re.FindStringSubmatch(input)
+
+	if len(regexResult) != 4 {
+		return BTNode{}, fmt.Errorf("parsing of line resulted in missing parts")
+	}
+
+	return BTNode{
+		id:    regexResult[1],
+		left:  regexResult[2],
+		right: regexResult[3],
+	}, nil
+}
+
+func parseInput(input []byte) (*Network, error) {
+	fileLines := strings.Split(string(input), "\n")
+
+	if len(fileLines) < 3 || fileLines[1] != "" {
+		return nil, &puzzle.ParseError{Line: 2, Err: fmt.Errorf("input should have instructions and nodes separated by an empty line")}
+	}
+	if index := strings.IndexFunc(fileLines[0], func(r rune) bool { return r != 'L' && r != 'R' }); index >= 0 || fileLines[0] == "" {
+		return nil, &puzzle.ParseError{Line: 1, Text: fileLines[0], Err: fmt.Errorf("instructions are a sequence of L and R")}
+	}
+	network := &Network{
+		instructions: fileLines[0],
+		nodes:        make(map[string]BTNode),
+	}
+
+	for index, line := range fileLines[2:] {
+		node, err := parseNode(line)
+
+		if err == nil {
+			network.nodes[node.id] = node
+		} else {
+			return nil, &puzzle.ParseError{Line: index + 3, Text: line, Err: err}
+		}
+	}
+	// The walks follow the children blindly, they must all have a line
+	for index, line := range fileLines[2:] {
+		node, _ := parseNode(line)
+		for _, child := range []string{node.left, node.right} {
+			if _, exists := network.nodes[child]; !exists {
+				return nil, &puzzle.ParseError{Line: index + 3, Text: line, Err: fmt.Errorf("node '%s' has no line of its own", child)}
+			}
+		}
+	}
+	return network, nil
+}
+
+func (network *Network) buildNodeGroup() NodeGroup {
+	group := make(NodeGroup, 0)
+
+	if !network.ghostNavigation {
+		node, exists := network.nodes["AAA"]
+		if exists {
+			group = append(group, &node)
+		}
+	} else {
+		for nodeIndex := range network.nodes {
+			node := network.nodes[nodeIndex]
+
+			if node.id[len(node.id)-1] == 'A' {
+				group = append(group, &node)
+			}
+		}
+	}
+	return group
+}
+
+// Steps from the node to a destination, -1 when the walk loops without
+// reaching one: past a step per node and instruction, a state came back.
+func (network *Network) evaluateCycleNumber(startingNodeId string) int {
+	instructions := network.instructions
+	startNode := network.nodes[startingNodeId]
+	currentNode := &startNode
+	loop := 0
+
+	for instructionIndex := 0; instructionIndex < len(instructions); {
+		if instructions[instructionIndex] == 'R' {
+			currentNode = network.walkRight(currentNode)
+		} else {
+			currentNode = network.walkLeft(currentNode)
+		}
+		loop++
+		if network.isFinalDestination(currentNode) {
+			break
+		}
+		if loop > len(network.nodes)*len(instructions) {
+			return -1
+		}
+		if instructionIndex == len(instructions)-1 {
+			instructionIndex = 0
+		} else {
+			instructionIndex++
+		}
+	}
+	return loop
+}
+
+// greatest common divisor (GCD) via Euclidean algorithm
+func GCD(a, b int) int {
+	for b != 0 {
+		t := b
+		b = a % b
+		a = t
+	}
+	return a
+}
+
+// LCM of every length, computed with math/big once an int overflows
+func lengthsLCM(lengths []int, useBigInt bool) any {
+	if useBigInt {
+		return lengthsLCMBig(big.NewInt(int64(lengths[0])), lengths[1:])
+	}
+	loop := lengths[0]
+
+	for index, length := range lengths[1:] {
+		next, ok := arith.Mul(loop/GCD(loop, length), length)
+		if !ok {
+			return lengthsLCMBig(big.NewInt(int64(loop)), lengths[index+1:])
+		}
+		loop = next
+	}
+	return loop
+}
+
+func lengthsLCMBig(loop *big.Int, lengths []int) any {
+	gcd := new(big.Int)
+
+	for _, length := range lengths {
+		bigLength := big.NewInt(int64(length))
+		gcd.GCD(nil, nil, loop, bigLength)
+		loop.Mul(loop.Div(loop, gcd), bigLength)
+	}
+	return arith.Answer(loop)
+}
+
+func solve(input []byte, ghostNavigation, useBigInt bool) (any, error) {
+	network, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
+	network.ghostNavigation = ghostNavigation
+	group := network.buildNodeGroup()
+
+	if len(group) == 0 {
+		return 0, fmt.Errorf("couldn't find any starting node")
+	}
+	// Every ghost walks on its own
+	pathLengths := workpool.Map(group, func(node *BTNode) int {
+		return network.evaluateCycleNumber(node.id)
+	})
+	for index, length := range pathLengths {
+		if length < 0 {
+			return 0, fmt.Errorf("no destination can be reached from %s", group[index].id)
+		}
+	}
+	if !ghostNavigation {
+		return pathLengths[0], nil
+	}
+	return lengthsLCM(pathLengths, useBigInt), nil
+}
+
+func explore(input []byte, _ puzzle.Options) ([]puzzle.Command, error) {
+	network, err := parseInput(input)
+	if err != nil {
+		return nil, err
+	}
+	instructions := network.instructions
+	findNode := func(id string) (BTNode, error) {
+		node, exists := network.nodes[id]
+		if !exists {
+			return BTNode{}, fmt.Errorf("no node named '%s'", id)
+		}
+		return node, nil
+	}
+
+	return []puzzle.Command{
+		{
+			Name:  "instructions",
+			Usage: "print the left/right instructions",
+			Run: func(_ []string, out io.Writer) error {
+				_, err := fmt.Fprintf(out, "%s (%d instructions)\n", instructions, len(instructions))
+				return err
+			},
+		},
+		{
+			Name:  "node",
+			Args:  "<id>",
+			Usage: "print the children of a node",
+			Run: func(args []string, out io.Writer) error {
+				if len(args) != 1 {
+					return fmt.Errorf("expected a node id")
+				}
+				node, err := findNode(args[0])
+				if err != nil {
+					return err
+				}
+				_, err = fmt.Fprintf(out, "%s = (%s, %s)\n", node.id, node.left, node.right)
+				return err
+			},
+		},
+		{
+			Name:  "starts",
+			Usage: "list the ghosts starting nodes (ending with A)",
+			Run: func(_ []string, out io.Writer) error {
+				ghosts := Network{nodes: network.nodes, ghostNavigation: true}
+				for _, node := range ghosts.buildNodeGroup() {
+					fmt.Fprintln(out, node.id)
+				}
+				return nil
+			},
+		},
+		{
+			Name:  "walk",
+			Args:  "<id> <steps>",
+			Usage: "follow the instructions from a node, nodes ending with Z are marked with *",
+			Run: func(args []string, out io.Writer) error {
+				if len(args) != 2 {
+					return fmt.Errorf("expected a node id and a step amount")
+				}
+				steps, err := strconv.Atoi(args[1])
+				if err != nil {
+					return err
+				}
+				node, err := findNode(args[0])
+				if err != nil {
+					return err
+				}
+				current := &node
+				fmt.Fprint(out, current.id)
+				for step := 0; step < steps; step++ {
+					if instructions[step%len(instructions)] == 'L' {
+						current = network.walkLeft(current)
+					} else {
+						current = network.walkRight(current)
+					}
+					if current == nil {
+						return fmt.Errorf("step %d leads to an unknown node", step+1)
+					}
+					fmt.Fprintf(out, " -%c-> %s", instructions[step%len(instructions)], current.id)
+					if current.id[len(current.id)-1] == 'Z' {
+						fmt.Fprint(out, "*")
+					}
+				}
+				fmt.Fprintln(out)
+				return nil
+			},
+		},
+	}, nil
+}
+
+func inspect(input []byte, _ puzzle.Options) ([]puzzle.Fact, error) {
+	network, err := parseInput(input)
+	if err != nil {
+		return nil, err
+	}
+	starts, ends := make([]string, 0), make([]string, 0)
+	for id := range network.nodes {
+		switch id[len(id)-1] {
+		case 'A':
+			starts = append(starts, id)
+		case 'Z':
+			ends = append(ends, id)
+		}
+	}
+	slices.Sort(starts)
+	slices.Sort(ends)
+	_, hasStart := network.nodes["AAA"]
+	_, hasEnd := network.nodes["ZZZ"]
+
+	return []puzzle.Fact{
+		{Name: "instructions", Value: len(network.instructions)},
+		{Name: "nodes", Value: len(network.nodes)},
+		{Name: "AAA and ZZZ", Value: hasStart && hasEnd},
+		{Name: "ghost starts", Value: strings.Join(starts, " ")},
+		{Name: "ghost ends", Value: strings.Join(ends, " ")},
+	}, nil
+}
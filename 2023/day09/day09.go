@@ -1,34 +1,37 @@
-package main
+package day09
 
 import (
-	"flag"
+	"embed"
 	"fmt"
-	"log"
-	"os"
+	"io"
 	"strconv"
 	"strings"
-)
 
-const (
-	inputFilename = "input.txt"
+	"bta/aoc23/puzzle"
 )
 
-var (
-	fileLines                []string
-	shouldReverseExtrapolate bool
-)
+//go:embed instructions.txt
+var files embed.FS
 
 func init() {
-	flag.BoolVar(&shouldReverseExtrapolate, "reverse", true, "Reverse extrapolate (push 0 instead of append)")
-	flag.Parse()
-}
-
-func init() {
-	file, err := os.ReadFile(inputFilename)
-	if err != nil {
-		log.Fatalln("couldn't open input file\n", err)
-	}
-	fileLines = strings.Split(string(file[:]), "\n")
+	puzzle.Register(puzzle.Day{
+		Year:   2023,
+		Number: 9,
+		Title:  "Mirage Maintenance",
+		Input:  "input.txt",
+		Files:  files,
+		Parts: [2]puzzle.Part{
+			{
+				Stream:   func(input io.Reader, _ puzzle.Options) (any, error) { return solve(input, false) },
+				Examples: []puzzle.Example{{Block: 0, Answer: "114"}},
+			},
+			// Reverse extrapolate (push 0 instead of append)
+			{
+				Stream:   func(input io.Reader, _ puzzle.Options) (any, error) { return solve(input, true) },
+				Examples: []puzzle.Example{{Block: 0, Answer: "2"}},
+			},
+		},
+	})
 }
 
 type IntSequence []int
@@ -94,18 +97,18 @@ func parseHistory(line string) (IntSequence, error) {
 	return sequence, nil
 }
 
-func main() {
+func solve(input io.Reader, shouldReverseExtrapolate bool) (int, error) {
+	lines := puzzle.Lines(input)
 	sum := 0
 
-	for _, line := range fileLines {
-		sequence, err := parseHistory(line)
+	for lines.Next() {
+		sequence, err := parseHistory(lines.Text())
 
 		if err == nil {
 			sum += sequence.extrapolate(shouldReverseExtrapolate)
 		} else {
-			log.Panicf("error during number parsing: %v", err)
+			return 0, lines.Fail(err)
 		}
 	}
-
-	fmt.Printf("result: %d\n", sum)
+	return sum, lines.Err()
 }
@@ -0,0 +1,448 @@
+package day10
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"bta/aoc23/geom"
+	"bta/aoc23/graph"
+	"bta/aoc23/puzzle"
+)
+
+//go:embed instructions.txt
+var files embed.FS
+
+func init() {
+	puzzle.Register(puzzle.Day{
+		Year:    2023,
+		Number:  10,
+		Title:   "Pipe Maze",
+		Input:   "input.txt",
+		Files:   files,
+		Grid:    true,
+		Explore: explore,
+		Inspect: inspect,
+		Parts: [2]puzzle.Part{
+			{
+				Solve:  solveFurthestTile,
+				Render: render,
+				Examples: []puzzle.Example{
+					{Block: 1, Answer: "4"},
+					{Block: 4, Answer: "8"},
+				},
+			},
+			{
+				Solve:  solveEnclosedTiles,
+				Render: render,
+				Examples: []puzzle.Example{
+					{Block: 9, Answer: "4"},
+					{Block: 11, Answer: "4"},
+					{Block: 12, Answer: "8"},
+					{Block: 14, Answer: "10"},
+				},
+			},
+		},
+	})
+}
+
+type PipeType rune
+
+const (
+	PIP_START PipeType = 'S'
+	PIP_EMPTY PipeType = '.'
+	PIP_VER   PipeType = '|'
+	PIP_HOR   PipeType = '-'
+	PIP_NTE   PipeType = 'L'
+	PIP_NTW   PipeType = 'J'
+	PIP_STE   PipeType = 'F'
+	PIP_STW   PipeType = '7'
+)
+
+type Color int8
+
+const (
+	COLOR_UNMARKED Color = 0
+	COLOR_RED      Color = 1
+	COLOR_BLUE     Color = -1
+)
+
+type Tile struct {
+	Type           PipeType
+	X, Y           int
+	TunnelProgress int
+	mark           Color
+}
+
+type TunnelMap struct {
+	Map         []Tile
+	MapHeight   int
+	MapWidth    int
+	StartingPos *Tile
+}
+
+// Directions each pipe connects to
+var pipeConnections = map[PipeType][2]geom.Dir{
+	PIP_VER: {geom.North, geom.South},
+	PIP_HOR: {geom.East, geom.West},
+	PIP_NTE: {geom.North, geom.East},
+	PIP_NTW: {geom.North, geom.West},
+	PIP_STE: {geom.South, geom.East},
+	PIP_STW: {geom.South, geom.West},
+}
+
+func (t Tile) To(direction geom.Dir) (x, y int) {
+	next := geom.Vec{X: t.X, Y: t.Y}.Move(direction, 1)
+
+	return next.X, next.Y
+}
+
+// Returns next tile's coordinate and heading when entering the tile heading to currentDirection (eg: '7' heading east
+// leads south), ok is false when the tile doesn't connect to the side it is entered by, the coordinates are then (-1, -1)
+func (t Tile) Go(currentDirection geom.Dir) (x, y int, newDirection geom.Dir, ok bool) {
+	connections, isPipe := pipeConnections[t.Type]
+	from := currentDirection.Reverse()
+
+	switch {
+	case !isPipe:
+		return -1, -1, currentDirection, false
+	case connections[0] == from:
+		newDirection = connections[1]
+	case connections[1] == from:
+		newDirection = connections[0]
+	default:
+		return -1, -1, currentDirection, false
+	}
+	x, y = t.To(newDirection)
+	return x, y, newDirection, true
+}
+
+// Returns tile pointer, nil if doesn't exist (out of bound)
+func (m TunnelMap) tileAt(x, y int) *Tile {
+	if 0 <= x && x < m.MapWidth && 0 <= y && y < m.MapHeight {
+
+		return &m.Map[x+m.MapWidth*y]
+	}
+	return nil
+}
+
+// It goes like [N, E, S, W], neighboors are indexed by the direction they are in and nil when they don't connect back
+func (m TunnelMap) findNeighboors(tile *Tile) []*Tile {
+	neighboors := make([]*Tile, len(geom.Dirs))
+
+	for _, direction := range geom.Dirs {
+		neighboor := m.tileAt(tile.To(direction))
+
+		if neighboor != nil {
+			if _, _, _, ok := neighboor.Go(direction); ok {
+				neighboors[direction] = neighboor
+			}
+		}
+	}
+	return neighboors
+}
+
+// Tiles the pipe of tile connects to and which connect back, the start
+// connecting to its first two connected neighboors
+func (m TunnelMap) pipeNeighboors(tile *Tile) []*Tile {
+	neighboors := make([]*Tile, 0, 2)
+
+	if tile == m.StartingPos {
+		for _, neighboor := range m.findNeighboors(tile) {
+			if neighboor != nil && len(neighboors) < 2 {
+				neighboors = append(neighboors, neighboor)
+			}
+		}
+		return neighboors
+	}
+	connections, isPipe := pipeConnections[tile.Type]
+	if !isPipe {
+		return nil
+	}
+	for _, direction := range connections {
+		neighboor := m.tileAt(tile.To(direction))
+		if neighboor == nil {
+			continue
+		}
+		if _, _, _, ok := neighboor.Go(direction); ok || neighboor == m.StartingPos {
+			neighboors = append(neighboors, neighboor)
+		}
+	}
+	return neighboors
+}
+
+// The loop is searched both ways from the start at once, the furthest tile
+// being where both ways meet
+func (m *TunnelMap) navigate() (int, error) {
+	switch connected := len(m.pipeNeighboors(m.StartingPos)); connected {
+	case 0:
+		return -1, fmt.Errorf("starting tile as no connection")
+	case 1:
+		return -1, fmt.Errorf("starting tile as only one connection")
+	}
+	paths := graph.BFS([]*Tile{m.StartingPos}, m.pipeNeighboors, nil)
+	furthest := 0
+
+	for tile, progress := range paths.Cost {
+		if len(m.pipeNeighboors(tile)) != 2 {
+			return -1, fmt.Errorf("the pipes from the start don't loop, (%d, %d) is a dead end", tile.X, tile.Y)
+		}
+		tile.TunnelProgress = progress
+		furthest = max(furthest, progress)
+	}
+	return furthest, nil
+}
+
+// The start hides the pipe connecting its two connected neighboors
+func (m *TunnelMap) identifyStartTileType() PipeType {
+	neighboors := m.findNeighboors(m.StartingPos)
+	connected := 0
+
+	for _, neighboor := range neighboors {
+		if neighboor != nil {
+			connected++
+		}
+	}
+	if connected != 2 {
+		return PIP_EMPTY
+	}
+	for pipe, connections := range pipeConnections {
+		if neighboors[connections[0]] != nil && neighboors[connections[1]] != nil {
+			return pipe
+		}
+	}
+	return PIP_EMPTY
+}
+
+func (m *TunnelMap) markZones() Color {
+	lastFromTop := false
+	isInside := false
+
+	for tileIndex := range m.Map {
+		currentTile := &m.Map[tileIndex]
+
+		if tileIndex%m.MapWidth == 0 {
+			isInside = false
+		}
+		if currentTile.TunnelProgress < 0 {
+			if isInside {
+				currentTile.mark = COLOR_RED
+			} else {
+				currentTile.mark = COLOR_BLUE
+			}
+		} else {
+			currentType := currentTile.Type
+
+			if currentType == PIP_START {
+				currentType = m.identifyStartTileType()
+			}
+			if currentType == PIP_HOR ||
+				(lastFromTop && (currentType == PIP_STE || currentType == PIP_STW)) ||
+				(!lastFromTop && (currentType == PIP_NTE || currentType == PIP_NTW)) {
+				continue
+			}
+			isInside = !isInside
+		}
+	}
+	return COLOR_RED
+}
+
+func initTunnelMap(input []byte) (TunnelMap, error) {
+	// The examples of the statement mark the ground inside and outside the
+	// loop with I and O
+	fileLines, err := puzzle.ParseGrid(input, "|-LJ7F.SIO")
+	if err != nil {
+		return TunnelMap{}, err
+	}
+
+	mapWidth := len(fileLines[0])
+	mapHeight := len(fileLines)
+
+	tunnelMap := TunnelMap{
+		Map:         make([]Tile, mapWidth*mapHeight),
+		MapHeight:   mapHeight,
+		MapWidth:    mapWidth,
+		StartingPos: nil,
+	}
+
+	for y, row := range fileLines {
+		for x, tile := range row {
+			tunnelMap.Map[y*mapWidth+x] = Tile{
+				X:              x,
+				Y:              y,
+				Type:           PipeType(tile),
+				TunnelProgress: -1,
+				mark:           COLOR_UNMARKED,
+			}
+			if tile == rune(PIP_START) {
+				tunnelMap.StartingPos = &tunnelMap.Map[y*mapWidth+x]
+			}
+		}
+	}
+	if tunnelMap.StartingPos == nil {
+		return TunnelMap{}, fmt.Errorf("couldn't find tunnel starting position")
+	}
+	return tunnelMap, nil
+}
+
+func solveMap(input []byte) (TunnelMap, int, error) {
+	tunnelMap, err := initTunnelMap(input)
+
+	if err != nil {
+		return TunnelMap{}, 0, fmt.Errorf("Error on file parsing: %w", err)
+	}
+
+	furthestTileDistance, err := tunnelMap.navigate()
+	if err != nil {
+		return TunnelMap{}, 0, err
+	}
+	return tunnelMap, furthestTileDistance, nil
+}
+
+func solveFurthestTile(input []byte, _ puzzle.Options) (any, error) {
+	_, furthestTileDistance, err := solveMap(input)
+
+	return furthestTileDistance, err
+}
+
+func solveEnclosedTiles(input []byte, _ puzzle.Options) (any, error) {
+	tunnelMap, _, err := solveMap(input)
+	if err != nil {
+		return nil, err
+	}
+	identifiedColor := tunnelMap.markZones()
+	enclosedTiles := 0
+
+	// The marked map is only drawn when asked for with aoc run -v
+	if slog.Default().Enabled(context.Background(), slog.LevelDebug) {
+		for y, row := range tunnelMap.draw() {
+			slog.Debug("marked map", "day", 10, "y", y, "row", row)
+		}
+	}
+
+	for _, tile := range tunnelMap.Map {
+		if tile.mark == identifiedColor {
+			enclosedTiles++
+		}
+	}
+	return enclosedTiles, nil
+}
+
+var pipeDrawing = map[PipeType]rune{
+	PIP_VER: '│',
+	PIP_HOR: '─',
+	PIP_NTE: '└',
+	PIP_NTW: '┘',
+	PIP_STE: '┌',
+	PIP_STW: '┐',
+}
+
+// Draws the loop with box characters, enclosed tiles as 'I' and the others as 'O'
+func render(input []byte, _ puzzle.Options) ([]string, error) {
+	tunnelMap, _, err := solveMap(input)
+	if err != nil {
+		return nil, err
+	}
+	tunnelMap.markZones()
+	return tunnelMap.draw(), nil
+}
+
+func (tunnelMap TunnelMap) draw() []string {
+	rows := make([]string, 0, tunnelMap.MapHeight)
+	row := make([]rune, 0, tunnelMap.MapWidth)
+
+	for index, tile := range tunnelMap.Map {
+		switch tile.mark {
+		case COLOR_UNMARKED:
+			pipe := tile.Type
+			if pipe == PIP_START {
+				pipe = tunnelMap.identifyStartTileType()
+			}
+			row = append(row, pipeDrawing[pipe])
+		case COLOR_RED:
+			row = append(row, 'I')
+		case COLOR_BLUE:
+			row = append(row, 'O')
+		}
+		if (index+1)%tunnelMap.MapWidth == 0 {
+			rows = append(rows, string(row))
+			row = row[:0]
+		}
+	}
+	return rows
+}
+
+var colorNames = map[Color]string{
+	COLOR_UNMARKED: "loop",
+	COLOR_RED:      "inside",
+	COLOR_BLUE:     "outside",
+}
+
+func explore(input []byte, _ puzzle.Options) ([]puzzle.Command, error) {
+	tunnelMap, furthestTileDistance, err := solveMap(input)
+	if err != nil {
+		return nil, err
+	}
+	tunnelMap.markZones()
+
+	return []puzzle.Command{
+		{
+			Name:  "start",
+			Usage: "print the starting tile and the pipe it hides",
+			Run: func(_ []string, out io.Writer) error {
+				_, err := fmt.Fprintf(out, "(%d, %d) is a '%c'\n", tunnelMap.StartingPos.X, tunnelMap.StartingPos.Y, tunnelMap.identifyStartTileType())
+				return err
+			},
+		},
+		{
+			Name:  "tile",
+			Args:  "<x> <y>",
+			Usage: "print a tile, its distance along the loop and its zone",
+			Run: func(args []string, out io.Writer) error {
+				coords, err := puzzle.IntArgs(args, 2)
+				if err != nil {
+					return err
+				}
+				tile := tunnelMap.tileAt(coords[0], coords[1])
+				if tile == nil {
+					return fmt.Errorf("(%d, %d) is out of the %dx%d map", coords[0], coords[1], tunnelMap.MapWidth, tunnelMap.MapHeight)
+				}
+				fmt.Fprintf(out, "(%d, %d) '%c' %s", tile.X, tile.Y, tile.Type, colorNames[tile.mark])
+				if tile.TunnelProgress >= 0 {
+					fmt.Fprintf(out, ", %d steps from start", tile.TunnelProgress)
+				}
+				fmt.Fprintln(out)
+				return nil
+			},
+		},
+		{
+			Name:  "furthest",
+			Usage: "list the loop tiles the furthest from the start",
+			Run: func(_ []string, out io.Writer) error {
+				for _, tile := range tunnelMap.Map {
+					if tile.TunnelProgress == furthestTileDistance {
+						fmt.Fprintf(out, "(%d, %d) '%c' %d steps from start\n", tile.X, tile.Y, tile.Type, tile.TunnelProgress)
+					}
+				}
+				return nil
+			},
+		},
+	}, nil
+}
+
+func inspect(input []byte, opts puzzle.Options) ([]puzzle.Fact, error) {
+	facts, err := puzzle.GridFacts(input, opts)
+	if err != nil {
+		return nil, err
+	}
+	tunnelMap, err := initTunnelMap(input)
+	if err != nil {
+		return nil, err
+	}
+	return append(facts,
+		puzzle.Fact{Name: "start", Value: fmt.Sprintf("(%d, %d)", tunnelMap.StartingPos.X, tunnelMap.StartingPos.Y)},
+		puzzle.Fact{Name: "start pipe", Value: string(tunnelMap.identifyStartTileType())},
+	), nil
+}
@@ -0,0 +1,151 @@
+package day11
+
+import (
+	"embed"
+	"math"
+	"math/big"
+	"slices"
+	"strings"
+
+	"bta/aoc23/arith"
+	"bta/aoc23/puzzle"
+)
+
+//go:embed instructions.txt
+var files embed.FS
+
+func init() {
+	puzzle.Register(puzzle.Day{
+		Year:   2023,
+		Number: 11,
+		Title:  "Cosmic Expansion",
+		Input:  "input.txt",
+		Files:  files,
+		Grid:   true,
+		Options: []puzzle.Option{
+			puzzle.BigInt,
+			{Name: "expansion", Usage: "rows and columns an empty one becomes, in part 1", Default: 2, Min: 1},
+			{Name: "older-expansion", Usage: "rows and columns an empty one becomes, in part 2", Default: 1000000, Min: 1},
+		},
+		Parts: [2]puzzle.Part{
+			{
+				Solve: func(input []byte, opts puzzle.Options) (any, error) {
+					return solve(input, opts.Get("expansion"), opts.Get("bigint") == 1)
+				},
+				Examples: []puzzle.Example{{Block: 0, Answer: "374"}},
+			},
+			// Use older galaxies
+			{
+				Solve: func(input []byte, opts puzzle.Options) (any, error) {
+					return solve(input, opts.Get("older-expansion"), opts.Get("bigint") == 1)
+				},
+				Examples: []puzzle.Example{{Block: 0, Answer: "82000210"}},
+			},
+		},
+	})
+}
+
+type Star struct {
+	X, Y int
+}
+
+type GameParams struct {
+	Width, Height int
+}
+
+func evaluateDistanceBetweenStars(start, destination Star) int {
+	xOffset := int(math.Abs(float64(destination.X - start.X)))
+	yOffset := int(math.Abs(float64(destination.Y - start.Y)))
+
+	return xOffset + yOffset
+}
+
+func pushStarsAfterColumn(stars []Star, x, galaxyOffset int) int {
+	toPush := make([]*Star, 0)
+
+	for indexOffset := 0; true; indexOffset++ {
+		starIndex := slices.IndexFunc(stars[indexOffset:], func(s Star) bool { return s.X > x })
+		if starIndex < 0 {
+			break
+		}
+		toPush = append(toPush, &stars[starIndex+indexOffset])
+		indexOffset += starIndex
+	}
+	for _, starPtr := range toPush {
+		starPtr.X += galaxyOffset - 1
+	}
+	return galaxyOffset - 1
+}
+
+func initStarIndex(input []byte, galaxyOffset int) (GameParams, []Star) {
+	fileLines := strings.Split(string(input), "\n")
+	stars := make([]Star, 0)
+	params := GameParams{
+		Height: len(fileLines),
+		Width:  len(fileLines[0]),
+	}
+	yTotalOffset := 0
+
+	for y, line := range fileLines {
+		starOnLine := 0
+		for x, b := range line {
+			if b == '#' {
+				starOnLine++
+				stars = append(stars, Star{
+					X: x,
+					Y: y + yTotalOffset,
+				})
+			}
+		}
+		if starOnLine == 0 {
+			yTotalOffset += galaxyOffset - 1
+		}
+	}
+	xTotalOffset := 0
+	for x := 0; x < params.Width; x++ {
+		if slices.IndexFunc(stars, func(s Star) bool {
+			return s.X-xTotalOffset == x
+		}) == -1 {
+			xTotalOffset += pushStarsAfterColumn(stars, x+xTotalOffset, galaxyOffset)
+		}
+	}
+	return GameParams{
+		Width:  params.Width + xTotalOffset,
+		Height: params.Height + yTotalOffset,
+	}, stars
+}
+
+func sumDistances(input []byte, galaxyOffset int) int {
+	_, stars := initStarIndex(input, galaxyOffset)
+	totalDistance := 0
+
+	for refIndex := range stars {
+		for starIndex := range stars[refIndex+1:] {
+			totalDistance += evaluateDistanceBetweenStars(stars[refIndex], stars[refIndex+starIndex+1])
+		}
+	}
+	return totalDistance
+}
+
+// Distances grow linearly with the expansion: the sum is the one of the
+// unexpanded universe plus (galaxyOffset-1) times the empty rows and columns
+// crossed, which is what an offset of 2 adds. Only this last step can
+// overflow, it is redone with math/big when it does.
+func solve(input []byte, galaxyOffset int, useBigInt bool) (any, error) {
+	if _, err := puzzle.ParseGrid(input, ".#"); err != nil {
+		return nil, err
+	}
+	unexpanded := sumDistances(input, 1)
+	crossed := sumDistances(input, 2) - unexpanded
+
+	if !useBigInt {
+		if expansion, ok := arith.Mul(galaxyOffset-1, crossed); ok {
+			if total, ok := arith.Add(unexpanded, expansion); ok {
+				return total, nil
+			}
+		}
+	}
+	total := big.NewInt(int64(galaxyOffset - 1))
+	total.Mul(total, big.NewInt(int64(crossed)))
+	return arith.Answer(total.Add(total, big.NewInt(int64(unexpanded)))), nil
+}
@@ -0,0 +1,59 @@
+package day11
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"bta/aoc23/proptest"
+)
+
+// Image of a small universe, a third of its cells being galaxies
+type universe []byte
+
+func (universe) Generate(rand *rand.Rand, _ int) reflect.Value {
+	width, height := 1+rand.Intn(8), 1+rand.Intn(8)
+	rows := make([]string, 0, height)
+
+	for y := 0; y < height; y++ {
+		row := []byte(strings.Repeat(".", width))
+		for x := range row {
+			if rand.Intn(3) == 0 {
+				row[x] = '#'
+			}
+		}
+		rows = append(rows, string(row))
+	}
+	return reflect.ValueOf(universe(strings.Join(rows, "\n")))
+}
+
+func distances(t *testing.T, image universe, expansion int) int {
+	sum, err := solve(image, expansion, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sum.(int)
+}
+
+func TestExpansionProperties(t *testing.T) {
+	proptest.Check(t, func(image universe, a, b uint16) bool {
+		low, high := 1+int(min(a, b)), 1+int(max(a, b))
+		lowSum, highSum := distances(t, image, low), distances(t, image, high)
+		if lowSum > highSum {
+			t.Logf("expansion %d sums %d, expansion %d sums %d", low, lowSum, high, highSum)
+			return false
+		}
+		return true
+	})
+
+	// The linear shortcut of solve against actually expanding the universe
+	proptest.Check(t, func(image universe, a uint8) bool {
+		expansion := 1 + int(a%20)
+		if shortcut, expanded := distances(t, image, expansion), sumDistances(image, expansion); shortcut != expanded {
+			t.Logf("expansion %d sums %d, expanding the universe sums %d", expansion, shortcut, expanded)
+			return false
+		}
+		return true
+	})
+}
@@ -0,0 +1,250 @@
+package day12
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"bta/aoc23/arith"
+	"bta/aoc23/puzzle"
+	"bta/aoc23/workpool"
+)
+
+//go:embed instructions.txt
+var files embed.FS
+
+func init() {
+	puzzle.Register(puzzle.Day{
+		Year:    2023,
+		Number:  12,
+		Title:   "Hot Springs",
+		Input:   "input.txt",
+		Files:   files,
+		Inspect: inspect,
+		Options: []puzzle.Option{
+			puzzle.BigInt,
+			{Name: "unfold", Usage: "copies of each row once unfolded, in part 2", Default: 5, Min: 1},
+		},
+		Parts: [2]puzzle.Part{
+			{
+				Stream: func(input io.Reader, opts puzzle.Options) (any, error) {
+					return solve(input, 1, opts.Get("bigint") == 1)
+				},
+				Examples: []puzzle.Example{{Block: 1, Answer: "21"}},
+			},
+			// unfold instructions in input.txt
+			{
+				Stream: func(input io.Reader, opts puzzle.Options) (any, error) {
+					return solve(input, opts.Get("unfold"), opts.Get("bigint") == 1)
+				},
+				Examples: []puzzle.Example{{Block: 1, Answer: "525152"}},
+			},
+		},
+	})
+}
+
+type Instruction struct {
+	inputString string
+	objective   []int
+}
+
+// The row is repeated unfold times, joined by '?'
+func parseInstruction(line string, unfold int) (Instruction, error) {
+	splitted := strings.Split(line, " ")
+
+	if len(splitted) != 2 {
+		return Instruction{}, fmt.Errorf("identified more than 2 parts in input, it should be exactly 2 parts separated by a space")
+	}
+	stringAmounts := strings.Split(splitted[1], ",")
+	amounts := make([]int, len(stringAmounts)*unfold)
+	inputString := strings.Repeat(splitted[0]+"?", unfold-1) + splitted[0]
+
+	for index, amountString := range stringAmounts {
+		if amount, parseError := strconv.Atoi(amountString); parseError == nil {
+			for copyIndex := 0; copyIndex < unfold; copyIndex++ {
+				amounts[index+len(stringAmounts)*copyIndex] = amount
+			}
+		} else {
+			return Instruction{}, fmt.Errorf("error parsing amount: %v", parseError)
+		}
+	}
+
+	return Instruction{
+		inputString: inputString,
+		objective:   amounts,
+	}, nil
+}
+
+// Rows are counted in batches, so that the stream is never held whole
+const rowBatchSize = 1024
+
+type State [3]int
+
+// States reached from state when reading srcChar, a '?' can lead to two
+func nextStates(state State, srcChar byte, objective []int) ([2]State, int) {
+	next := [2]State{}
+	amount := 0
+	challengeSucceeded, hits, requiredEmpty := state[0], state[1], state[2]
+
+	switch {
+	case (srcChar == '#' || srcChar == '?') && challengeSucceeded < len(objective) && requiredEmpty == 0:
+		if srcChar == '?' && hits <= 0 {
+			next[amount] = State{challengeSucceeded, hits, requiredEmpty}
+			amount++
+		}
+		hits++
+		if hits == objective[challengeSucceeded] {
+			challengeSucceeded, hits, requiredEmpty = challengeSucceeded+1, 0, 1
+		}
+		next[amount] = State{challengeSucceeded, hits, requiredEmpty}
+		amount++
+	case (srcChar == '.' || srcChar == '?') && hits <= 0:
+		requiredEmpty = 0
+		next[amount] = State{challengeSucceeded, hits, requiredEmpty}
+		amount++
+	}
+	return next, amount
+}
+
+// Counts the arrangements of a row, ok is false when the count overflows
+func countPossibilities(instruction Instruction) (int, bool) {
+	total := 0
+	src := []byte(instruction.inputString)
+	states := map[State]int{{0, 0, 0}: 1}
+	nStates := map[State]int{}
+
+	for _, srcChar := range src {
+		for state, quantity := range states {
+			next, amount := nextStates(state, srcChar, instruction.objective)
+
+			for _, nextState := range next[:amount] {
+				sum, ok := arith.Add(nStates[nextState], quantity)
+				if !ok {
+					return 0, false
+				}
+				nStates[nextState] = sum
+			}
+		}
+		states, nStates = nStates, states
+		clear(nStates)
+	}
+
+	for state, amount := range states {
+		if state[0] == len(instruction.objective) {
+			sum, ok := arith.Add(total, amount)
+			if !ok {
+				return 0, false
+			}
+			total = sum
+		}
+	}
+	return total, true
+}
+
+func countPossibilitiesBig(instruction Instruction) *big.Int {
+	total := new(big.Int)
+	src := []byte(instruction.inputString)
+	states := map[State]*big.Int{{0, 0, 0}: big.NewInt(1)}
+	nStates := map[State]*big.Int{}
+
+	for _, srcChar := range src {
+		for state, quantity := range states {
+			next, amount := nextStates(state, srcChar, instruction.objective)
+
+			for _, nextState := range next[:amount] {
+				if nStates[nextState] == nil {
+					nStates[nextState] = new(big.Int)
+				}
+				nStates[nextState].Add(nStates[nextState], quantity)
+			}
+		}
+		states, nStates = nStates, states
+		clear(nStates)
+	}
+
+	for state, amount := range states {
+		if state[0] == len(instruction.objective) {
+			total.Add(total, amount)
+		}
+	}
+	return total
+}
+
+func solve(input io.Reader, unfold int, useBigInt bool) (any, error) {
+	lines := puzzle.Lines(input)
+	batch := make([]Instruction, 0, rowBatchSize)
+	total := arith.Sum{}
+
+	if useBigInt {
+		total.UseBig()
+	}
+	// Rows are counted with ints, and again with math/big when they overflow
+	countBatch := func() {
+		for _, possibilities := range workpool.Map(batch, func(instruction Instruction) arith.Sum {
+			count := arith.Sum{}
+			if useBigInt {
+				count.AddBig(countPossibilitiesBig(instruction))
+			} else if value, ok := countPossibilities(instruction); ok {
+				count.Add(value)
+			} else {
+				count.AddBig(countPossibilitiesBig(instruction))
+			}
+			return count
+		}) {
+			total.AddSum(possibilities)
+		}
+		batch = batch[:0]
+	}
+
+	for lines.Next() {
+		instruction, err := parseInstruction(lines.Text(), unfold)
+		if err != nil {
+			return 0, lines.Fail(err)
+		}
+		if batch = append(batch, instruction); len(batch) == rowBatchSize {
+			countBatch()
+		}
+	}
+	if err := lines.Err(); err != nil {
+		return 0, err
+	}
+	countBatch()
+	return total.Value(), nil
+}
+
+func inspect(input []byte, opts puzzle.Options) ([]puzzle.Fact, error) {
+	lines := puzzle.Lines(bytes.NewReader(input))
+	rows, longestRun, mostUnknowns, mostGroups := 0, 0, 0, 0
+	longest := Instruction{}
+
+	for lines.Next() {
+		instruction, err := parseInstruction(lines.Text(), opts.Get("unfold"))
+		if err != nil {
+			return nil, lines.Fail(err)
+		}
+		rows++
+		folded := instruction.inputString[:strings.IndexByte(lines.Text(), ' ')]
+		for _, run := range strings.FieldsFunc(folded, func(r rune) bool { return r != '?' }) {
+			longestRun = max(longestRun, len(run))
+		}
+		mostUnknowns = max(mostUnknowns, strings.Count(folded, "?"))
+		mostGroups = max(mostGroups, len(instruction.objective)/opts.Get("unfold"))
+		if len(instruction.inputString) > len(longest.inputString) {
+			longest = instruction
+		}
+	}
+	if err := lines.Err(); err != nil {
+		return nil, err
+	}
+	return []puzzle.Fact{
+		{Name: "rows", Value: rows},
+		{Name: "longest ? run", Value: longestRun},
+		{Name: "most ? in a row", Value: mostUnknowns},
+		{Name: "most groups in a row", Value: mostGroups},
+		{Name: "longest unfolded row", Value: fmt.Sprintf("%d springs, %d groups", len(longest.inputString), len(longest.objective))},
+	}, nil
+}
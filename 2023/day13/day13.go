@@ -0,0 +1,199 @@
+package day13
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+
+	"bta/aoc23/puzzle"
+	"bta/aoc23/workpool"
+)
+
+//go:embed instructions.txt
+var files embed.FS
+
+func init() {
+	puzzle.Register(puzzle.Day{
+		Year:    2023,
+		Number:  13,
+		Title:   "Point of Incidence",
+		Input:   "input.txt",
+		Files:   files,
+		Inspect: inspect,
+		Parts: [2]puzzle.Part{
+			{
+				Solve:    func(input []byte, _ puzzle.Options) (any, error) { return solve(input, false) },
+				Examples: []puzzle.Example{{Block: 0, Answer: "405"}},
+			},
+			// all mirrors have exactly ONE sludge to fix
+			{
+				Solve:    func(input []byte, _ puzzle.Options) (any, error) { return solve(input, true) },
+				Examples: []puzzle.Example{{Block: 0, Answer: "400"}},
+			},
+		},
+	})
+}
+
+type GroundMap []string
+
+func lineDiff(a, b string) int {
+	diff := 0
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			diff++
+		}
+	}
+	return diff + int(math.Abs(float64(len(a)-len(b))))
+}
+
+func (m GroundMap) checkHorizontalMirrorAt(index int, hasSmudge bool) bool {
+	diff := 0
+
+	for i := 0; (index+i < len(m)) && (index-i > 0); i++ {
+		diff += lineDiff(m[index+i], m[index-(i+1)])
+		if (hasSmudge && diff > 1) || (!hasSmudge && m[index+i] != m[index-(i+1)]) {
+			return false
+		}
+	}
+	return index > 0 && (!hasSmudge || diff == 1)
+}
+
+func (m GroundMap) makeColumnBuffer() []byte {
+	return make([]byte, len(m))
+}
+
+func (m GroundMap) fillColumnBuffer(index int, buff []byte) {
+	for i := 0; i < len(m); i++ {
+		buff[i] = m[i][index]
+	}
+}
+
+func (m GroundMap) checkVerticalMirrorAt(index int, hasSmudge bool) bool {
+	diff := 0
+	verticalBuffer := [][]byte{
+		m.makeColumnBuffer(),
+		m.makeColumnBuffer(),
+	}
+	for i := 0; (index+i < len(m[0])) && (index-i > 0); i++ {
+		m.fillColumnBuffer(index-(i+1), verticalBuffer[0])
+		m.fillColumnBuffer(index+i, verticalBuffer[1])
+		diff += lineDiff(string(verticalBuffer[0]), string(verticalBuffer[1]))
+		if (hasSmudge && diff > 1) || (!hasSmudge && !slices.Equal(verticalBuffer[0], verticalBuffer[1])) {
+			return false
+		}
+	}
+	return index > 0 && (!hasSmudge || diff == 1)
+}
+
+func (m GroundMap) Solve(hasSmudge bool) (bool, int) {
+	verticalLimit := len(m)
+	horizontalLimit := len(m[0])
+	limit := int(math.Max(float64(verticalLimit), float64(horizontalLimit)))
+	hLineBuffer := [1]string{}
+	vLineBuffer := [2][]byte{
+		m.makeColumnBuffer(),
+		m.makeColumnBuffer(),
+	}
+
+	for i := 0; i < limit; i++ {
+		if i < verticalLimit {
+			if m.checkHorizontalMirrorAt(i, hasSmudge) {
+				return true, i * 100
+			}
+			hLineBuffer[0] = m[i]
+		}
+		if i < horizontalLimit {
+			m.fillColumnBuffer(i, vLineBuffer[1])
+			if m.checkVerticalMirrorAt(i, hasSmudge) {
+				return true, i
+			}
+			vLineBuffer[0] = slices.Insert(vLineBuffer[0][:0], 0, vLineBuffer[1]...)
+		}
+	}
+	return false, -1
+}
+
+// Patterns are separated by an empty line, each one must be a grid
+func parsePatterns(input []byte) ([]GroundMap, error) {
+	reader := bufio.NewScanner(bytes.NewReader(input))
+	lineBuffer := make([]string, 0)
+	patterns := make([]GroundMap, 0)
+
+	for lineNumber := 1; ; lineNumber++ {
+		shouldExit := !reader.Scan()
+		line := reader.Text()
+
+		if line == "" {
+			if _, err := puzzle.ParseGrid([]byte(strings.Join(lineBuffer, "\n")), ".#"); err != nil {
+				// Lines of the whole input rather than of the pattern
+				parseErr := err.(*puzzle.ParseError)
+				parseErr.Line += lineNumber - len(lineBuffer) - 1
+				return nil, parseErr
+			}
+			patterns = append(patterns, GroundMap(lineBuffer))
+			lineBuffer = make([]string, 0)
+		} else {
+			lineBuffer = append(lineBuffer, line)
+		}
+		if shouldExit {
+			break
+		}
+	}
+	return patterns, nil
+}
+
+func solve(input []byte, hasSmudge bool) (int, error) {
+	patterns, err := parsePatterns(input)
+	if err != nil {
+		return 0, err
+	}
+
+	// Patterns don't depend on each other
+	results := workpool.Map(patterns, func(groundMap GroundMap) int {
+		hasMirror, index := groundMap.Solve(hasSmudge)
+		if !hasMirror {
+			slog.Warn("no mirror?", "day", 13, "height", len(groundMap))
+			return 0
+		}
+		return index
+	})
+
+	sum := 0
+	for _, value := range results {
+		sum += value
+	}
+	return sum, nil
+}
+
+func inspect(input []byte, _ puzzle.Options) ([]puzzle.Fact, error) {
+	patterns, err := parsePatterns(input)
+	if err != nil {
+		return nil, err
+	}
+	histogram := puzzle.Histogram{}
+	smallest, largest := patterns[0], patterns[0]
+
+	for _, pattern := range patterns {
+		for _, line := range pattern {
+			histogram.Add(line)
+		}
+		if len(pattern)*len(pattern[0]) < len(smallest)*len(smallest[0]) {
+			smallest = pattern
+		}
+		if len(pattern)*len(pattern[0]) > len(largest)*len(largest[0]) {
+			largest = pattern
+		}
+	}
+	return []puzzle.Fact{
+		{Name: "patterns", Value: len(patterns)},
+		{Name: "smallest pattern", Value: fmt.Sprintf("%dx%d", len(smallest[0]), len(smallest))},
+		{Name: "largest pattern", Value: fmt.Sprintf("%dx%d", len(largest[0]), len(largest))},
+		{Name: "characters", Value: histogram},
+	}, nil
+}
@@ -0,0 +1,105 @@
+package day13
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"bta/aoc23/proptest"
+)
+
+// Small pattern, which is mirrored around a random line half of the time so
+// that the mirrors aren't all found by chance
+func (GroundMap) Generate(rand *rand.Rand, _ int) reflect.Value {
+	width, height := 1+rand.Intn(9), 1+rand.Intn(9)
+	rows := make([][]byte, height)
+
+	for y := range rows {
+		rows[y] = make([]byte, width)
+		for x := range rows[y] {
+			rows[y][x] = ".#"[rand.Intn(2)]
+		}
+	}
+	switch rand.Intn(4) {
+	case 0:
+		if height > 1 {
+			line := 1 + rand.Intn(height-1)
+			for i := 0; line+i < height && line-i > 0; i++ {
+				copy(rows[line+i], rows[line-i-1])
+			}
+		}
+	case 1:
+		if width > 1 {
+			line := 1 + rand.Intn(width-1)
+			for _, row := range rows {
+				for i := 0; line+i < width && line-i > 0; i++ {
+					row[line+i] = row[line-i-1]
+				}
+			}
+		}
+	}
+
+	m := make(GroundMap, 0, height)
+	for _, row := range rows {
+		m = append(m, string(row))
+	}
+	return reflect.ValueOf(m)
+}
+
+func transpose(m GroundMap) GroundMap {
+	transposed := make(GroundMap, 0, len(m[0]))
+	for x := range m[0] {
+		column := make([]byte, 0, len(m))
+		for _, row := range m {
+			column = append(column, row[x])
+		}
+		transposed = append(transposed, string(column))
+	}
+	return transposed
+}
+
+// Line of a Solve result, and whether it is horizontal. Patterns are less than
+// 100 wide.
+func mirrorLine(value int) (int, bool) {
+	if value >= 100 {
+		return value / 100, true
+	}
+	return value, false
+}
+
+func TestTransposeProperties(t *testing.T) {
+	proptest.Check(t, func(m GroundMap, hasSmudge bool) bool {
+		transposed := transpose(m)
+
+		for i := 0; i <= len(m) || i <= len(m[0]); i++ {
+			if i <= len(m) && m.checkHorizontalMirrorAt(i, hasSmudge) != transposed.checkVerticalMirrorAt(i, hasSmudge) {
+				t.Logf("%q: horizontal line %d isn't the vertical one of the transposed pattern", m, i)
+				return false
+			}
+			if i <= len(m[0]) && m.checkVerticalMirrorAt(i, hasSmudge) != transposed.checkHorizontalMirrorAt(i, hasSmudge) {
+				t.Logf("%q: vertical line %d isn't the horizontal one of the transposed pattern", m, i)
+				return false
+			}
+		}
+
+		found, value := m.Solve(hasSmudge)
+		transposedFound, transposedValue := transposed.Solve(hasSmudge)
+		if found != transposedFound {
+			t.Logf("%q: found %v, found %v in the transposed pattern", m, found, transposedFound)
+			return false
+		}
+		if !found {
+			return true
+		}
+		line, horizontal := mirrorLine(value)
+		transposedLine, transposedHorizontal := mirrorLine(transposedValue)
+		// Solve looks for the horizontal line first, so both results are
+		// horizontal when the pattern mirrors both ways around the same line
+		bothWays := m.checkHorizontalMirrorAt(line, hasSmudge) && m.checkVerticalMirrorAt(line, hasSmudge)
+		if line != transposedLine || (horizontal == transposedHorizontal && !bothWays) {
+			t.Logf("%q: solved %d, solved %d in the transposed pattern", m, value, transposedValue)
+			return false
+		}
+		return true
+	})
+}
@@ -0,0 +1,137 @@
+package day14
+
+import (
+	"embed"
+	"log/slog"
+	"strings"
+
+	"bta/aoc23/puzzle"
+)
+
+const (
+	DEFAULT_MAXLOOP = 1000000000
+)
+
+//go:embed instructions.txt
+var files embed.FS
+
+func init() {
+	puzzle.Register(puzzle.Day{
+		Year:    2023,
+		Number:  14,
+		Title:   "Parabolic Reflector Dish",
+		Input:   "input.txt",
+		Files:   files,
+		Grid:    true,
+		Inspect: puzzle.GridFacts,
+		Options: []puzzle.Option{
+			{Name: "cycles", Usage: "spin cycles run before weighing the load", Default: DEFAULT_MAXLOOP},
+		},
+		Parts: [2]puzzle.Part{
+			{},
+			{
+				Solve: func(input []byte, opts puzzle.Options) (any, error) {
+					return solve(input, opts.Get("cycles"))
+				},
+				Examples: []puzzle.Example{{Block: 0, Answer: "64"}},
+			},
+		},
+	})
+}
+
+// Quarter turn clockwise, into a new matrix as the platform needn't be square
+func rotateMatrix[T any](matrix [][]T) [][]T {
+	rotated := make([][]T, len(matrix[0]))
+
+	for i := range rotated {
+		rotated[i] = make([]T, len(matrix))
+		for j := range matrix {
+			rotated[i][j] = matrix[len(matrix)-1-j][i]
+		}
+	}
+	return rotated
+}
+
+// Reimplemented sorting to make sure of the sorting algorithm
+func BubbleSort[T any](array []T, sortFx func(a, b T) bool) []T {
+	for i := 0; i < len(array)-1; i++ {
+		for j := 0; j < len(array)-i-1; j++ {
+			if sortFx(array[j], array[j+1]) {
+				array[j], array[j+1] = array[j+1], array[j]
+			}
+		}
+	}
+	return array
+}
+func RollBalls(a, b byte) bool {
+	return b == '.' && a == 'O'
+}
+
+func evaluateBallWeight(m [][]byte) int {
+	sum := 0
+	for _, line := range m {
+		for index, char := range line {
+			if char == 'O' {
+				// Use index in line (actually column) to determine ball score
+				sum += index + 1
+			}
+		}
+	}
+	return sum
+}
+
+// Tilts north, west, south then east, the platform is rotated back to where
+// it started
+func spinCycle(fileLines [][]byte) [][]byte {
+	for direction := 0; direction < 4; direction++ {
+		for _, line := range fileLines {
+			// Roll balls to the end of line
+			BubbleSort(line, RollBalls)
+		}
+		fileLines = rotateMatrix(fileLines)
+	}
+	return fileLines
+}
+
+func platformState(fileLines [][]byte) string {
+	state := strings.Builder{}
+	for _, line := range fileLines {
+		state.Write(line)
+	}
+	return state.String()
+}
+
+func solve(input []byte, cycles int) (int, error) {
+	linesAsString, err := puzzle.ParseGrid(input, ".#O")
+	if err != nil {
+		return 0, err
+	}
+	fileLines := make([][]byte, 0, len(linesAsString))
+	// Cycle index each platform state is first seen after, and the load after
+	// each cycle
+	firstSeen := make(map[string]int)
+	loads := make([]int, 0)
+
+	for _, line := range linesAsString {
+		fileLines = append(fileLines, []byte(line))
+	}
+	// Rotate Right (North on right)
+	fileLines = rotateMatrix(fileLines)
+
+	for i := 0; i < cycles; i++ {
+		if i%1000 == 0 {
+			slog.Debug("spin cycles run", "day", 14, "cycles", i)
+		}
+		fileLines = spinCycle(fileLines)
+		state := platformState(fileLines)
+
+		if first, seen := firstSeen[state]; seen {
+			// Cycles from first on repeat, the last one is somewhere in the loop
+			loopLength := i - first
+			return loads[first+(cycles-1-first)%loopLength], nil
+		}
+		firstSeen[state] = i
+		loads = append(loads, evaluateBallWeight(fileLines))
+	}
+	return evaluateBallWeight(fileLines), nil
+}
@@ -0,0 +1,71 @@
+package day14
+
+import (
+	"strings"
+	"testing"
+)
+
+var example = strings.Join([]string{
+	"O....#....",
+	"O.OO#....#",
+	".....##...",
+	"OO.#O....O",
+	".O.....O#.",
+	"O.#..O.#.#",
+	"..O..#O..O",
+	".......O..",
+	"#....###..",
+	"#OO..#....",
+}, "\n")
+
+// Runs every cycle, without looking for a loop
+func bruteForce(input string, cycles int) int {
+	fileLines := make([][]byte, 0)
+	for _, line := range strings.Split(input, "\n") {
+		fileLines = append(fileLines, []byte(line))
+	}
+	fileLines = rotateMatrix(fileLines)
+	for i := 0; i < cycles; i++ {
+		fileLines = spinCycle(fileLines)
+	}
+	return evaluateBallWeight(fileLines)
+}
+
+func TestCycles(t *testing.T) {
+	for cycles := 0; cycles <= 100; cycles++ {
+		if got, err := solve([]byte(example), cycles); err != nil || got != bruteForce(example, cycles) {
+			t.Errorf("%d cycles: load %d (%v), expected %d", cycles, got, err, bruteForce(example, cycles))
+		}
+	}
+	if got, err := solve([]byte(example), DEFAULT_MAXLOOP); err != nil || got != 64 {
+		t.Errorf("%d cycles: load %d (%v), expected 64", DEFAULT_MAXLOOP, got, err)
+	}
+}
+
+// Walls on the right and on top of a platform change neither where the rocks
+// stop nor the load, they make it square
+func TestNonSquare(t *testing.T) {
+	for _, platform := range [][]string{
+		{"O.#", "..O"},
+		{"O.", ".O", "#."},
+		{".O..#", "O.#.O", "..O.."},
+	} {
+		size := max(len(platform), len(platform[0]))
+		square := make([]string, 0, size)
+		for len(square)+len(platform) < size {
+			square = append(square, strings.Repeat("#", size))
+		}
+		for _, row := range platform {
+			square = append(square, row+strings.Repeat("#", size-len(row)))
+		}
+
+		input := strings.Join(platform, "\n")
+		for cycles := 0; cycles <= 20; cycles++ {
+			got, err := solve([]byte(input), cycles)
+			expected, _ := solve([]byte(strings.Join(square, "\n")), cycles)
+			if err != nil || got != expected || got != bruteForce(input, cycles) {
+				t.Errorf("%q, %d cycles: load %d (%v), expected %d", platform, cycles, got, err, expected)
+			}
+		}
+	}
+}
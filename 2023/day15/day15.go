@@ -0,0 +1,235 @@
+package day15
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"bta/aoc23/puzzle"
+)
+
+type Code uint8
+
+type Lens struct {
+	Label string
+	Power int
+}
+
+//go:embed instructions.txt
+var files embed.FS
+
+func init() {
+	puzzle.Register(puzzle.Day{
+		Year:    2023,
+		Number:  15,
+		Title:   "Lens Library",
+		Input:   "input.txt",
+		Files:   files,
+		Explore: explore,
+		Parts: [2]puzzle.Part{
+			{
+				Stream:   func(input io.Reader, _ puzzle.Options) (any, error) { return hashSum(input) },
+				Examples: []puzzle.Example{{Block: 0, Answer: "1320"}},
+			},
+			{
+				Stream:   func(input io.Reader, _ puzzle.Options) (any, error) { return focusingPower(input) },
+				Examples: []puzzle.Example{{Block: 0, Answer: "145"}},
+			},
+		},
+	})
+}
+
+func mapStringToCode(s string) Code {
+	initialValue := Code(0)
+
+	// Byte by byte, ranging over s would skip the rest of multibyte runes
+	for charIndex := 0; charIndex < len(s); charIndex++ {
+		initialValue += Code(s[charIndex])
+		initialValue *= 17
+	}
+	return initialValue
+}
+
+func hashSum(input io.Reader) (int, error) {
+	codes := puzzle.Values(input, ',')
+	result := 0
+
+	for codes.Next() {
+		result += int(mapStringToCode(codes.Text()))
+	}
+	return result, codes.Err()
+}
+
+// Boxes of lenses, filled by the steps of the initialization sequence
+type Library map[Code][]Lens
+
+// Applies a step of the initialization sequence, returns the box it changed
+func (boxes Library) Apply(code string) (Code, error) {
+	parts := strings.Split(code, "=")
+	label := parts[0]
+
+	if len(label) >= 2 && label[len(label)-1] == '-' {
+		label := label[0 : len(label)-1]
+		boxIndex := mapStringToCode(label)
+		_, mapCreated := boxes[boxIndex]
+
+		if !mapCreated {
+			return boxIndex, nil
+		}
+		boxes[boxIndex] = slices.DeleteFunc(boxes[boxIndex], func(l Lens) bool {
+			return l.Label == label
+		})
+		return boxIndex, nil
+	} else if len(parts) == 2 {
+		boxIndex := mapStringToCode(label)
+		_, mapCreated := boxes[boxIndex]
+
+		if !mapCreated {
+			boxes[boxIndex] = make([]Lens, 0)
+		}
+		if lensId, parseErr := strconv.Atoi(parts[1]); parseErr == nil {
+			lens := Lens{
+				Label: label,
+				Power: lensId,
+			}
+			if lensIndex := slices.IndexFunc(boxes[boxIndex], func(l Lens) bool {
+				return l.Label == label
+			}); lensIndex != -1 {
+				boxes[boxIndex][lensIndex] = lens
+			} else {
+				boxes[boxIndex] = append(boxes[boxIndex], lens)
+			}
+		}
+		return boxIndex, nil
+	}
+	return 0, fmt.Errorf("label has no '-' nor '={[0-9]}'?? (%s)", code)
+}
+
+func (boxes Library) Power() int {
+	sum := 0
+	for boxIndex, box := range boxes {
+		for lensIndex, lens := range box {
+			sum += (int(boxIndex) + 1) * (lensIndex + 1) * lens.Power
+		}
+	}
+	return sum
+}
+
+func focusingPower(input io.Reader) (int, error) {
+	codes := puzzle.Values(input, ',')
+	boxes := make(Library)
+
+	for codes.Next() {
+		if _, err := boxes.Apply(codes.Text()); err != nil {
+			return 0, codes.Fail(err)
+		}
+	}
+	if err := codes.Err(); err != nil {
+		return 0, err
+	}
+	return boxes.Power(), nil
+}
+
+func explore(input []byte, _ puzzle.Options) ([]puzzle.Command, error) {
+	codes := strings.Split(string(input), ",")
+	boxes := make(Library)
+	applied := 0
+
+	printBox := func(out io.Writer, boxIndex Code) {
+		fmt.Fprintf(out, "box %d:", boxIndex)
+		for _, lens := range boxes[boxIndex] {
+			fmt.Fprintf(out, " [%s %d]", lens.Label, lens.Power)
+		}
+		fmt.Fprintln(out)
+	}
+
+	return []puzzle.Command{
+		{
+			Name:  "step",
+			Args:  "[amount]",
+			Usage: "apply the next steps of the initialization sequence (1 by default)",
+			Run: func(args []string, out io.Writer) error {
+				amount := 1
+				if len(args) > 0 {
+					values, err := puzzle.IntArgs(args, 1)
+					if err != nil {
+						return err
+					}
+					amount = values[0]
+				}
+				for ; amount > 0 && applied < len(codes); amount-- {
+					boxIndex, err := boxes.Apply(codes[applied])
+					if err != nil {
+						return err
+					}
+					fmt.Fprintf(out, "step %d: %s\n", applied+1, codes[applied])
+					printBox(out, boxIndex)
+					applied++
+				}
+				if applied == len(codes) {
+					fmt.Fprintln(out, "initialization sequence is over")
+				}
+				return nil
+			},
+		},
+		{
+			Name:  "box",
+			Args:  "<number>",
+			Usage: "print the lenses of a box",
+			Run: func(args []string, out io.Writer) error {
+				values, err := puzzle.IntArgs(args, 1)
+				if err != nil {
+					return err
+				}
+				if values[0] < 0 || values[0] > 255 {
+					return fmt.Errorf("boxes are numbered from 0 to 255")
+				}
+				printBox(out, Code(values[0]))
+				return nil
+			},
+		},
+		{
+			Name:  "boxes",
+			Usage: "print every box holding lenses",
+			Run: func(_ []string, out io.Writer) error {
+				for boxIndex := 0; boxIndex < 256; boxIndex++ {
+					if len(boxes[Code(boxIndex)]) > 0 {
+						printBox(out, Code(boxIndex))
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Name:  "hash",
+			Args:  "<string>",
+			Usage: "run the HASH algorithm on a string",
+			Run: func(args []string, out io.Writer) error {
+				if len(args) != 1 {
+					return fmt.Errorf("expected a string to hash")
+				}
+				_, err := fmt.Fprintln(out, mapStringToCode(args[0]))
+				return err
+			},
+		},
+		{
+			Name:  "power",
+			Usage: "print the focusing power after the applied steps",
+			Run: func(_ []string, out io.Writer) error {
+				_, err := fmt.Fprintf(out, "%d (after %d/%d steps)\n", boxes.Power(), applied, len(codes))
+				return err
+			},
+		},
+		{
+			Name:  "reset",
+			Usage: "empty the boxes and restart the sequence",
+			Run: func(_ []string, _ io.Writer) error {
+				boxes, applied = make(Library), 0
+				return nil
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,22 @@
+package day15
+
+import (
+	"testing"
+
+	"bta/aoc23/proptest"
+)
+
+// HASH as written in the instructions
+func referenceHash(s string) int {
+	value := 0
+	for _, c := range []byte(s) {
+		value = (value + int(c)) * 17 % 256
+	}
+	return value
+}
+
+func TestHashProperties(t *testing.T) {
+	proptest.Check(t, func(s string) bool {
+		return int(mapStringToCode(s)) == referenceHash(s)
+	})
+}
@@ -0,0 +1,295 @@
+package day16
+
+import (
+	"embed"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"bta/aoc23/geom"
+	"bta/aoc23/graph"
+	"bta/aoc23/puzzle"
+	"bta/aoc23/workpool"
+)
+
+//go:embed instructions.txt
+var files embed.FS
+
+func init() {
+	puzzle.Register(puzzle.Day{
+		Year:    2023,
+		Number:  16,
+		Title:   "The Floor Will Be Lava",
+		Input:   "input.txt",
+		Files:   files,
+		Grid:    true,
+		Inspect: puzzle.GridFacts,
+		Parts: [2]puzzle.Part{
+			{
+				Solve:    solveFromTopLeft,
+				Render:   render,
+				Examples: []puzzle.Example{{Block: 0, Answer: "46"}},
+			},
+			// Search for the maximum energized tiles
+			{
+				Solve: func(input []byte, _ puzzle.Options) (any, error) {
+					mirrorMap, err := parseMirrorMap(input)
+					if err != nil {
+						return nil, err
+					}
+					return mirrorMap.SearchMax(), nil
+				},
+				Examples: []puzzle.Example{{Block: 0, Answer: "51"}},
+			},
+		},
+	})
+}
+
+type Cursor struct {
+	position  geom.Vec
+	direction geom.Dir
+}
+
+func (c *Cursor) Move() {
+	c.position = c.position.Move(c.direction, 1)
+}
+
+func (c Cursor) IsInBoundary(m MirrorMap) bool {
+	return c.position.X >= 0 && c.position.Y >= 0 && c.position.X < m.GetWidth() && c.position.Y < m.GetHeight()
+}
+
+type MirrorTile struct {
+	tile      ETile
+	energized bool
+}
+
+type ETile byte
+
+const (
+	TIL_EMPTY           ETile = '.'
+	TIL_MIRROR_FORWARD  ETile = '/'
+	TIL_MIRROR_BACKWARD ETile = '\\'
+	TIL_SPLITTER_VER    ETile = '|'
+	TIL_SPLITTER_HOR    ETile = '-'
+)
+
+func (t ETile) MapDirection(input geom.Dir) []geom.Dir {
+	if slices.Contains([]ETile{TIL_EMPTY}, t) {
+		return []geom.Dir{input}
+	} else if (t == TIL_SPLITTER_HOR && input.IsHorizontal()) || (t == TIL_SPLITTER_VER && input.IsVertical()) {
+		return []geom.Dir{input}
+	} else if t == TIL_SPLITTER_HOR {
+		return []geom.Dir{geom.Left, geom.Right}
+	} else if t == TIL_SPLITTER_VER {
+		return []geom.Dir{geom.Up, geom.Down}
+	} else if t == TIL_MIRROR_FORWARD {
+		return []geom.Dir{input.AntiTranspose()}
+	} else if t == TIL_MIRROR_BACKWARD {
+		return []geom.Dir{input.Transpose()}
+	}
+	return []geom.Dir{}
+}
+
+type MirrorMap [][]MirrorTile
+
+func newMirrorMapFromByteArray(b []byte) MirrorMap {
+	m := make(MirrorMap, 0)
+
+	for len(b) > 0 {
+		cutIndex := slices.Index(b, '\n')
+
+		if cutIndex != -1 {
+			tileArr := make([]MirrorTile, cutIndex)
+			for index := range tileArr {
+				tileArr[index] = MirrorTile{
+					tile:      ETile(b[index]),
+					energized: false,
+				}
+			}
+			b = b[cutIndex+1:]
+			m = append(m, tileArr)
+		} else {
+			tileArr := make([]MirrorTile, len(b))
+			for index := range tileArr {
+				tileArr[index] = MirrorTile{
+					tile:      ETile(b[index]),
+					energized: false,
+				}
+			}
+			m = append(m, tileArr)
+			break
+		}
+	}
+	return m
+}
+
+func (m MirrorMap) Display() []string {
+	rows := make([]string, 0, len(m))
+
+	for _, line := range m {
+		var row strings.Builder
+
+		for _, tile := range line {
+			if tile.energized {
+				row.WriteByte('#')
+			} else {
+				row.WriteByte(byte(tile.tile))
+			}
+		}
+		rows = append(rows, row.String())
+	}
+	return rows
+}
+
+func (m MirrorMap) CountEnergized() int {
+	sum := 0
+
+	for _, line := range m {
+		for _, tile := range line {
+			if tile.energized {
+				sum++
+			}
+		}
+	}
+	return sum
+}
+
+func (m MirrorMap) Reset() int {
+	sum := 0
+
+	for lineIndex := range m {
+		for tileIndex := range m[lineIndex] {
+			m[lineIndex][tileIndex].energized = false
+		}
+	}
+	return sum
+}
+
+func (m MirrorMap) GetWidth() int {
+	if len(m) <= 0 {
+		return 0
+	}
+	return len(m[0])
+}
+
+func (m MirrorMap) GetHeight() int {
+	return len(m)
+}
+
+// Cursors the beam of cursor goes on to, split ones included
+func (m MirrorMap) nextCursors(cursor Cursor) []Cursor {
+	newDirections := m[cursor.position.Y][cursor.position.X].tile.MapDirection(cursor.direction)
+	nextCursors := make([]Cursor, 0, len(newDirections))
+
+	for _, newDirection := range newDirections {
+		newCursor := Cursor{
+			position:  cursor.position,
+			direction: newDirection,
+		}
+		newCursor.Move()
+
+		if newCursor.IsInBoundary(m) {
+			nextCursors = append(nextCursors, newCursor)
+		}
+	}
+	return nextCursors
+}
+
+// Beams can loop, the cursors are the nodes of a graph whose reachable ones
+// energize their tile
+func (m MirrorMap) RunSimulation(startCursor Cursor) {
+	for cursor := range graph.Reachable([]Cursor{startCursor}, m.nextCursors) {
+		m[cursor.position.Y][cursor.position.X].energized = true
+	}
+}
+
+// Copy of the map, with its own energized state
+func (m MirrorMap) Clone() MirrorMap {
+	clone := make(MirrorMap, len(m))
+
+	for lineIndex, line := range m {
+		clone[lineIndex] = slices.Clone(line)
+	}
+	return clone
+}
+
+func (m MirrorMap) SearchMax() int {
+
+	mapWidth := m.GetWidth()
+	mapHeight := m.GetHeight()
+	perimeter := mapWidth*2 + mapHeight*2
+	starts := make([]Cursor, perimeter)
+
+	for i := 0; i < perimeter; i++ {
+		var x, y int
+		var dir geom.Dir
+
+		if i < mapWidth*2 {
+			x = i % mapWidth
+			if i < mapWidth {
+				y = 0
+				dir = geom.Down
+			} else {
+				y = mapHeight - 1
+				dir = geom.Up
+			}
+		} else {
+			y = (i - (mapWidth * 2)) % mapHeight
+			if i-(mapWidth*2) < mapHeight {
+				dir = geom.Right
+				x = 0
+			} else {
+				dir = geom.Left
+				x = mapWidth - 1
+			}
+		}
+		starts[i] = Cursor{
+			position:  geom.Vec{X: x, Y: y},
+			direction: dir,
+		}
+	}
+
+	// Simulations mark the tiles they cross, every worker gets its own map
+	energized := workpool.MapLocal(starts, m.Clone, func(local MirrorMap, start Cursor) int {
+		local.RunSimulation(start)
+		defer local.Reset()
+		slog.Debug("start simulated", "day", 16, "x", start.position.X, "y", start.position.Y, "direction", start.direction)
+		return local.CountEnergized()
+	})
+	return slices.Max(energized)
+}
+
+func parseMirrorMap(input []byte) (MirrorMap, error) {
+	if _, err := puzzle.ParseGrid(input, "./\\|-"); err != nil {
+		return nil, err
+	}
+	return newMirrorMapFromByteArray(input), nil
+}
+
+func energizeFromTopLeft(input []byte) (MirrorMap, error) {
+	mirrorMap, err := parseMirrorMap(input)
+	if err != nil {
+		return nil, err
+	}
+	mirrorMap.RunSimulation(Cursor{
+		position:  geom.Vec{X: 0, Y: 0},
+		direction: geom.Right,
+	})
+	return mirrorMap, nil
+}
+
+func solveFromTopLeft(input []byte, _ puzzle.Options) (any, error) {
+	mirrorMap, err := energizeFromTopLeft(input)
+	if err != nil {
+		return nil, err
+	}
+	return mirrorMap.CountEnergized(), nil
+}
+
+func render(input []byte, _ puzzle.Options) ([]string, error) {
+	mirrorMap, err := energizeFromTopLeft(input)
+	if err != nil {
+		return nil, err
+	}
+	return mirrorMap.Display(), nil
+}
@@ -0,0 +1,177 @@
+package day17
+
+import (
+	"embed"
+	"fmt"
+	"math"
+
+	"bta/aoc23/geom"
+	"bta/aoc23/graph"
+	"bta/aoc23/puzzle"
+)
+
+//go:embed instructions.txt
+var files embed.FS
+
+func init() {
+	puzzle.Register(puzzle.Day{
+		Year:    2023,
+		Number:  17,
+		Title:   "Clumsy Crucible",
+		Input:   "input.txt",
+		Files:   files,
+		Grid:    true,
+		Inspect: puzzle.GridFacts,
+		Options: []puzzle.Option{
+			{Name: "min-move", Usage: "least blocks the crucible moves before turning", Default: 1, Min: 1},
+			{Name: "max-move", Usage: "most blocks the crucible moves before turning", Default: 3, Min: 1},
+			{Name: "ultra-min-move", Usage: "least blocks the ultra crucible moves before turning", Default: 4, Min: 1},
+			{Name: "ultra-max-move", Usage: "most blocks the ultra crucible moves before turning", Default: 10, Min: 1},
+		},
+		Parts: [2]puzzle.Part{
+			{
+				Solve:    solver(""),
+				Render:   renderer(""),
+				Examples: []puzzle.Example{{Block: 0, Answer: "102"}},
+			},
+			{
+				Solve:  solver("ultra-"),
+				Render: renderer("ultra-"),
+				Examples: []puzzle.Example{
+					{Block: 0, Answer: "94"},
+					{Block: 3, Answer: "71"},
+				},
+			},
+		},
+	})
+}
+
+type Cursor struct {
+	Coords geom.Vec
+	// The cursor moves along this direction next, both ways: it is Right or
+	// Down, and turns by transposing it
+	Dir geom.Dir
+}
+
+// Returns the least heatloss and the cursors of the path taken, from the start to the end
+func findPath(grid map[geom.Vec]int, end geom.Vec, minMove, maxMove int) (int, []Cursor) {
+	starts := []Cursor{
+		{Coords: geom.Vec{X: 0, Y: 0}, Dir: geom.Down},
+		{Coords: geom.Vec{X: 0, Y: 0}, Dir: geom.Right},
+	}
+	moves := func(cursor Cursor) []graph.Edge[Cursor] {
+		edges := make([]graph.Edge[Cursor], 0, 2*(maxMove-minMove+1))
+
+		for i := -maxMove; i <= maxMove; i++ {
+			n := cursor.Coords.Move(cursor.Dir, i)
+			if _, ok := grid[n]; !ok || i > -minMove && i < minMove {
+				continue
+			}
+			heatlossStreak, sign := 0, int(math.Copysign(1, float64(i)))
+			for j := sign; j != i+sign; j += sign {
+				heatlossStreak += grid[cursor.Coords.Move(cursor.Dir, j)]
+			}
+			edges = append(edges, graph.Edge[Cursor]{To: Cursor{n, cursor.Dir.Transpose()}, Cost: heatlossStreak})
+		}
+		return edges
+	}
+	paths := graph.Dijkstra(starts, moves, func(cursor Cursor) bool { return cursor.Coords == end })
+
+	if !paths.Found {
+		return -1, nil
+	}
+	return paths.Cost[paths.End], paths.Path(paths.End)
+}
+
+func parseGrid(input []byte) (map[geom.Vec]int, geom.Vec, error) {
+	lines, err := puzzle.ParseGrid(input, "0123456789")
+	if err != nil {
+		return nil, geom.Vec{}, err
+	}
+	grid, end := map[geom.Vec]int{}, geom.Vec{}
+
+	for y, line := range lines {
+		for x, chr := range line {
+			actualCoord := geom.Vec{X: x, Y: y}
+
+			grid[actualCoord] = int(chr - '0')
+			end = actualCoord
+		}
+	}
+	return grid, end, nil
+}
+
+// Move limits of the crucible, the options of the ultra crucible are
+// prefixed by "ultra-"
+func moveLimits(opts puzzle.Options, prefix string) (int, int, error) {
+	minMove, maxMove := opts.Get(prefix+"min-move"), opts.Get(prefix+"max-move")
+
+	if minMove > maxMove {
+		return 0, 0, fmt.Errorf("%smin-move (%d) is above %smax-move (%d)", prefix, minMove, prefix, maxMove)
+	}
+	return minMove, maxMove, nil
+}
+
+func solver(prefix string) puzzle.Solver {
+	return func(input []byte, opts puzzle.Options) (any, error) {
+		minMove, maxMove, err := moveLimits(opts, prefix)
+		if err != nil {
+			return 0, err
+		}
+		grid, end, err := parseGrid(input)
+		if err != nil {
+			return nil, err
+		}
+		heatloss, _ := findPath(grid, end, minMove, maxMove)
+
+		return heatloss, nil
+	}
+}
+
+// Draws the path over the grid the same way the instructions do
+func renderer(prefix string) puzzle.Renderer {
+	return func(input []byte, opts puzzle.Options) ([]string, error) {
+		minMove, maxMove, err := moveLimits(opts, prefix)
+		if err != nil {
+			return nil, err
+		}
+		grid, end, err := parseGrid(input)
+		if err != nil {
+			return nil, err
+		}
+		_, path := findPath(grid, end, minMove, maxMove)
+		rows := make([][]byte, end.Y+1)
+
+		for y := range rows {
+			rows[y] = make([]byte, end.X+1)
+			for x := range rows[y] {
+				rows[y][x] = byte('0' + grid[geom.Vec{X: x, Y: y}])
+			}
+		}
+		for i := 1; i < len(path); i++ {
+			from, to := path[i-1].Coords, path[i].Coords
+			move := to.Sub(from)
+			dir, _ := geom.DirOf(geom.Vec{X: sign(move.X), Y: sign(move.Y)})
+
+			for p := from.Move(dir, 1); p != to.Move(dir, 1); p = p.Move(dir, 1) {
+				rows[p.Y][p.X] = dir.Arrow()
+			}
+		}
+		result := make([]string, len(rows))
+		for y := range rows {
+			result[y] = string(rows[y])
+		}
+		return result, nil
+	}
+}
+
+func sign(x int) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,279 @@
+package day18
+
+import (
+	"embed"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"bta/aoc23/arith"
+	"bta/aoc23/geom"
+	"bta/aoc23/puzzle"
+)
+
+const (
+	maxRenderSize = 1000
+)
+
+var (
+	instructionRegex = regexp.MustCompile(`([URDL]) ([0-9]+) \(#([0-9a-z]{6})\)`)
+)
+
+type DigInstruction struct {
+	Direction geom.Dir
+	Length    int
+}
+
+//go:embed instructions.txt
+var files embed.FS
+
+func init() {
+	puzzle.Register(puzzle.Day{
+		Year:    2023,
+		Number:  18,
+		Title:   "Lavaduct Lagoon",
+		Input:   "input.txt",
+		Files:   files,
+		Options: []puzzle.Option{puzzle.BigInt},
+		Inspect: inspect,
+		Parts: [2]puzzle.Part{
+			{
+				Stream:   solver(false),
+				Render:   render,
+				Examples: []puzzle.Example{{Block: 0, Answer: "62"}},
+			},
+			// Sets the color in the input as both length and direction code
+			{
+				Stream:   solver(true),
+				Examples: []puzzle.Example{{Block: 0, Answer: "952408144115"}},
+			},
+		},
+	})
+}
+
+func ParseInputLine(line string, colorIsLength bool) (DigInstruction, error) {
+	parsed := instructionRegex.FindStringSubmatch(line)
+
+	if len(parsed) != 4 {
+		return DigInstruction{}, fmt.Errorf("line parsing error: '%s' isn't a dig instruction", line)
+	}
+	var direction geom.Dir
+	var length int
+	var parsingErr error
+
+	if colorIsLength {
+		length64, parsing64Err := strconv.ParseInt(parsed[3][:5], 16, strconv.IntSize)
+		length = int(length64)
+		parsingErr = parsing64Err
+		// The last digit counts quarter turns from right: 0 R, 1 D, 2 L, 3 U
+		directionCode := parsed[3][5]
+		if directionCode < '0' || directionCode > '3' {
+			return DigInstruction{}, fmt.Errorf("line parsing error: '%c' isn't a direction code", directionCode)
+		}
+		direction = geom.Right.Turn(int(directionCode - '0'))
+	} else {
+		var directionErr error
+		if direction, directionErr = geom.ParseDir(rune(parsed[1][0])); directionErr != nil {
+			return DigInstruction{}, fmt.Errorf("line parsing error: %v", directionErr)
+		}
+		length, parsingErr = strconv.Atoi(parsed[2])
+	}
+
+	if parsingErr != nil {
+		return DigInstruction{}, fmt.Errorf("line parsing error: %v", parsingErr)
+	}
+	return DigInstruction{
+		Direction: direction,
+		Length:    length,
+	}, nil
+}
+
+func ParseInput(input []byte, colorIsLength bool) ([]DigInstruction, error) {
+	lines := strings.Split(string(input), "\n")
+	instructions := make([]DigInstruction, 0, len(lines))
+
+	for _, line := range lines {
+		instruction, err := ParseInputLine(line, colorIsLength)
+		if err != nil {
+			return nil, err
+		}
+		instructions = append(instructions, instruction)
+	}
+	return instructions, nil
+}
+
+// Shoelace formula (plus the trench itself), summed one instruction at a
+// time so that the instructions can be streamed. Terms overflowing an int
+// are summed with math/big. The cross sum is negative for the plans dug
+// counter-clockwise, the trench isn't.
+type Area struct {
+	position  image.Point
+	cross     arith.Sum
+	perimeter arith.Sum
+}
+
+func (area *Area) Dig(instruction DigInstruction) error {
+	length := instruction.Length
+	a := area.position
+	unit := instruction.Direction.Point()
+	moveX, okX := arith.Mul(unit.X, length)
+	moveY, okY := arith.Mul(unit.Y, length)
+	bX, okBX := arith.Add(a.X, moveX)
+	bY, okBY := arith.Add(a.Y, moveY)
+
+	if !okX || !okY || !okBX || !okBY {
+		return fmt.Errorf("the trench goes further than %d meters", math.MaxInt)
+	}
+	b := image.Point{bX, bY}
+
+	if term, ok := shoelaceTerm(a, b); ok {
+		area.cross.Add(term)
+	} else {
+		term := new(big.Int).Mul(big.NewInt(int64(a.X)), big.NewInt(int64(b.Y)))
+		area.cross.AddBig(term.Sub(term, new(big.Int).Mul(big.NewInt(int64(a.Y)), big.NewInt(int64(b.X)))))
+	}
+	area.perimeter.Add(length)
+	area.position = b
+	return nil
+}
+
+// a.X*b.Y - a.Y*b.X, ok is false when it overflows
+func shoelaceTerm(a, b image.Point) (int, bool) {
+	left, okLeft := arith.Mul(a.X, b.Y)
+	right, okRight := arith.Mul(a.Y, b.X)
+	cross, okCross := arith.Sub(left, right)
+
+	return cross, okLeft && okRight && okCross
+}
+
+func (area *Area) Value() any {
+	value := area.cross.Big()
+	value.Abs(value).Add(value, area.perimeter.Big())
+	value.Quo(value, big.NewInt(2))
+	return arith.Answer(value.Add(value, big.NewInt(1)))
+}
+
+func EvaluateArea(instructions []DigInstruction) (any, error) {
+	area := Area{}
+
+	for _, instruction := range instructions {
+		if err := area.Dig(instruction); err != nil {
+			return nil, err
+		}
+	}
+	return area.Value(), nil
+}
+
+func solver(colorIsLength bool) puzzle.StreamSolver {
+	return func(input io.Reader, opts puzzle.Options) (any, error) {
+		lines := puzzle.Lines(input)
+		area := Area{}
+
+		if opts.Get("bigint") == 1 {
+			area.cross.UseBig()
+			area.perimeter.UseBig()
+		}
+		for lines.Next() {
+			instruction, err := ParseInputLine(lines.Text(), colorIsLength)
+			if err != nil {
+				return nil, lines.Fail(err)
+			}
+			if err := area.Dig(instruction); err != nil {
+				return nil, lines.Fail(err)
+			}
+		}
+		if err := lines.Err(); err != nil {
+			return nil, err
+		}
+		return area.Value(), nil
+	}
+}
+
+// Draws the trench as '#' and the dug out interior as '~'
+func render(input []byte, _ puzzle.Options) ([]string, error) {
+	instructions, err := ParseInput(input, false)
+	if err != nil {
+		return nil, err
+	}
+	trench := map[image.Point]bool{}
+	bounds := image.Rectangle{}
+	position := image.Point{0, 0}
+
+	for _, instruction := range instructions {
+		for i := 0; i < instruction.Length; i++ {
+			position = position.Add(instruction.Direction.Point())
+			trench[position] = true
+		}
+		bounds = bounds.Union(image.Rectangle{position, position.Add(image.Point{1, 1})})
+	}
+	if bounds.Dx() > maxRenderSize || bounds.Dy() > maxRenderSize {
+		return nil, fmt.Errorf("lagoon is too large to be rendered (%dx%d)", bounds.Dx(), bounds.Dy())
+	}
+
+	// Flood the outside from a border one tile larger than the lagoon
+	outside := bounds.Inset(-1)
+	flooded := map[image.Point]bool{outside.Min: true}
+	stack := []image.Point{outside.Min}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, direction := range geom.Dirs {
+			next := current.Add(direction.Point())
+			if next.In(outside) && !trench[next] && !flooded[next] {
+				flooded[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+
+	rows := make([]string, 0, bounds.Dy())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		var row strings.Builder
+
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			switch point := (image.Point{x, y}); {
+			case trench[point]:
+				row.WriteByte('#')
+			case flooded[point]:
+				row.WriteByte('.')
+			default:
+				row.WriteByte('~')
+			}
+		}
+		rows = append(rows, row.String())
+	}
+	return rows, nil
+}
+
+// Bounding box and trench length of the dig plan, read either way
+func inspect(input []byte, _ puzzle.Options) ([]puzzle.Fact, error) {
+	facts := []puzzle.Fact{}
+
+	for _, colorIsLength := range []bool{false, true} {
+		instructions, err := ParseInput(input, colorIsLength)
+		if err != nil {
+			return nil, err
+		}
+		position, extent, perimeter := image.Point{}, image.Rectangle{}, 0
+		for _, instruction := range instructions {
+			position = position.Add(instruction.Direction.Point().Mul(instruction.Length))
+			extent = extent.Union(image.Rectangle{Min: position, Max: position.Add(image.Pt(1, 1))})
+			perimeter += instruction.Length
+		}
+		name := "color plan"
+		if !colorIsLength {
+			name = "plan"
+			facts = append(facts, puzzle.Fact{Name: "instructions", Value: len(instructions)})
+		}
+		facts = append(facts,
+			puzzle.Fact{Name: name + " extent", Value: fmt.Sprintf("%dx%d, from %v to %v", extent.Dx(), extent.Dy(), extent.Min, extent.Max.Sub(image.Pt(1, 1)))},
+			puzzle.Fact{Name: name + " trench", Value: perimeter},
+		)
+	}
+	return facts, nil
+}
@@ -0,0 +1,131 @@
+package day18
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"bta/aoc23/geom"
+	"bta/aoc23/proptest"
+)
+
+// Dig plan of a histogram: columns of random widths and heights dug
+// clockwise from the bottom left corner, so the trench never crosses itself.
+// Half of the plans are mirrored or reversed, which digs them
+// counter-clockwise.
+type histogram []DigInstruction
+
+// Left and right swapped
+func mirror(plan []DigInstruction) []DigInstruction {
+	mirrored := make([]DigInstruction, 0, len(plan))
+	for _, instruction := range plan {
+		if instruction.Direction.IsHorizontal() {
+			instruction.Direction = instruction.Direction.Reverse()
+		}
+		mirrored = append(mirrored, instruction)
+	}
+	return mirrored
+}
+
+// Same trench, dug the other way around
+func reverse(plan []DigInstruction) []DigInstruction {
+	reversed := make([]DigInstruction, 0, len(plan))
+	for index := len(plan) - 1; index >= 0; index-- {
+		reversed = append(reversed, DigInstruction{Direction: plan[index].Direction.Reverse(), Length: plan[index].Length})
+	}
+	return reversed
+}
+
+func (histogram) Generate(rand *rand.Rand, _ int) reflect.Value {
+	plan := histogram{}
+	height, width := 0, 0
+
+	for columns := 1 + rand.Intn(6); columns > 0; columns-- {
+		next := 1 + rand.Intn(20)
+		switch {
+		case next > height:
+			plan = append(plan, DigInstruction{Direction: geom.Up, Length: next - height})
+		case next < height:
+			plan = append(plan, DigInstruction{Direction: geom.Down, Length: height - next})
+		}
+		step := 1 + rand.Intn(20)
+		plan = append(plan, DigInstruction{Direction: geom.Right, Length: step})
+		height, width = next, width+step
+	}
+	plan = append(plan,
+		DigInstruction{Direction: geom.Down, Length: height},
+		DigInstruction{Direction: geom.Left, Length: width})
+
+	switch rand.Intn(4) {
+	case 0:
+		plan = mirror(plan)
+	case 1:
+		plan = reverse(plan)
+	}
+	return reflect.ValueOf(plan)
+}
+
+func area(plan []DigInstruction) (int, error) {
+	value, err := EvaluateArea(plan)
+	if err != nil {
+		return 0, err
+	}
+	count, ok := value.(int)
+	if !ok {
+		return 0, fmt.Errorf("area %v isn't an int", value)
+	}
+	return count, nil
+}
+
+func TestAreaProperties(t *testing.T) {
+	square := []DigInstruction{{geom.Right, 2}, {geom.Up, 2}, {geom.Left, 2}, {geom.Down, 2}}
+	for _, plan := range [][]DigInstruction{square, reverse(square)} {
+		if value, err := area(plan); err != nil || value != 9 {
+			t.Errorf("%v: area %d (%v), expected 9", plan, value, err)
+		}
+	}
+
+	proptest.Check(t, func(plan histogram) bool {
+		reference, err := area(plan)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+		perimeter := 0
+		for _, instruction := range plan {
+			perimeter += instruction.Length
+		}
+		if reference < perimeter/2+1 {
+			t.Logf("area %d is smaller than the trench, perimeter %d", reference, perimeter)
+			return false
+		}
+
+		// Mirroring the plan, reversing it, rotating it a quarter turn at a
+		// time and starting it from another corner dig the same lagoon
+		for name, other := range map[string][]DigInstruction{"mirrored": mirror(plan), "reversed": reverse(plan)} {
+			if value, err := area(other); err != nil || value != reference {
+				t.Logf("%s: area %d (%v), expected %d", name, value, err, reference)
+				return false
+			}
+		}
+		for turns := 1; turns < 4; turns++ {
+			rotated := make([]DigInstruction, 0, len(plan))
+			for _, instruction := range plan {
+				rotated = append(rotated, DigInstruction{Direction: instruction.Direction.Turn(turns), Length: instruction.Length})
+			}
+			if value, err := area(rotated); err != nil || value != reference {
+				t.Logf("%d quarter turns: area %d (%v), expected %d", turns, value, err, reference)
+				return false
+			}
+		}
+		for shift := 1; shift < len(plan); shift++ {
+			shifted := append(append([]DigInstruction{}, plan[shift:]...), plan[:shift]...)
+			if value, err := area(shifted); err != nil || value != reference {
+				t.Logf("starting from instruction %d: area %d (%v), expected %d", shift, value, err, reference)
+				return false
+			}
+		}
+		return true
+	})
+}
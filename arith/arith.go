@@ -0,0 +1,92 @@
+// Package arith holds overflow checked int operations, the solvers switch to
+// math/big when one of them fails instead of printing a wrapped answer.
+package arith
+
+import (
+	"math"
+	"math/big"
+)
+
+// Add returns a+b, ok is false when it overflows.
+func Add(a, b int) (int, bool) {
+	sum := a + b
+	return sum, (sum > a) == (b > 0)
+}
+
+// Sub returns a-b, ok is false when it overflows.
+func Sub(a, b int) (int, bool) {
+	difference := a - b
+	return difference, (difference < a) == (b > 0)
+}
+
+// Mul returns a*b, ok is false when it overflows.
+func Mul(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	product := a * b
+	if (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) || product/b != a {
+		return product, false
+	}
+	return product, true
+}
+
+// Answer returns value as an int when it fits in one, so that small answers
+// stay plain ints whatever the arithmetic they were computed with.
+func Answer(value *big.Int) any {
+	if value.IsInt64() && value.Int64() >= math.MinInt && value.Int64() <= math.MaxInt {
+		return int(value.Int64())
+	}
+	return value
+}
+
+// Sum accumulates ints, and moves to math/big once they overflow.
+type Sum struct {
+	value int
+	exact *big.Int
+}
+
+// UseBig makes the sum exact from now on, for the -bigint option.
+func (s *Sum) UseBig() {
+	if s.exact == nil {
+		s.exact = big.NewInt(int64(s.value))
+	}
+}
+
+func (s *Sum) Add(value int) {
+	if s.exact == nil {
+		if sum, ok := Add(s.value, value); ok {
+			s.value = sum
+			return
+		}
+		s.UseBig()
+	}
+	s.exact.Add(s.exact, big.NewInt(int64(value)))
+}
+
+func (s *Sum) AddBig(value *big.Int) {
+	s.UseBig()
+	s.exact.Add(s.exact, value)
+}
+
+// AddSum adds another sum, it stays an int sum while both fit.
+func (s *Sum) AddSum(other Sum) {
+	if other.exact != nil {
+		s.AddBig(other.exact)
+	} else {
+		s.Add(other.value)
+	}
+}
+
+// Big returns a copy of the sum as a big.Int.
+func (s *Sum) Big() *big.Int {
+	if s.exact == nil {
+		return big.NewInt(int64(s.value))
+	}
+	return new(big.Int).Set(s.exact)
+}
+
+// Value returns the sum as an int, or as a *big.Int when it doesn't fit.
+func (s *Sum) Value() any {
+	return Answer(s.Big())
+}
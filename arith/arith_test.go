@@ -0,0 +1,119 @@
+package arith
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestOperations(t *testing.T) {
+	tests := []struct {
+		name   string
+		op     func(a, b int) (int, bool)
+		a, b   int
+		result int
+		ok     bool
+	}{
+		{"Add", Add, 2, 3, 5, true},
+		{"Add", Add, -2, -3, -5, true},
+		{"Add", Add, math.MaxInt, -1, math.MaxInt - 1, true},
+		{"Add", Add, math.MaxInt, 1, 0, false},
+		{"Add", Add, math.MinInt, -1, 0, false},
+		{"Add", Add, math.MinInt, math.MaxInt, -1, true},
+		{"Add", Add, 0, 0, 0, true},
+		{"Sub", Sub, 2, 3, -1, true},
+		{"Sub", Sub, math.MinInt, 1, 0, false},
+		{"Sub", Sub, math.MaxInt, -1, 0, false},
+		{"Sub", Sub, 0, math.MinInt, 0, false},
+		{"Sub", Sub, -1, math.MinInt, math.MaxInt, true},
+		{"Sub", Sub, math.MinInt, math.MinInt, 0, true},
+		{"Mul", Mul, 6, -7, -42, true},
+		{"Mul", Mul, 0, math.MinInt, 0, true},
+		{"Mul", Mul, math.MinInt, 1, math.MinInt, true},
+		{"Mul", Mul, math.MinInt, -1, 0, false},
+		{"Mul", Mul, -1, math.MinInt, 0, false},
+		{"Mul", Mul, math.MaxInt, -1, -math.MaxInt, true},
+		{"Mul", Mul, math.MaxInt/2 + 1, 2, 0, false},
+		{"Mul", Mul, math.MinInt / 2, 2, math.MinInt, true},
+		{"Mul", Mul, math.MinInt/2 - 1, 2, 0, false},
+		{"Mul", Mul, math.MaxInt/3 + 1, 3, 0, false},
+	}
+	for _, test := range tests {
+		result, ok := test.op(test.a, test.b)
+		if ok != test.ok || (ok && result != test.result) {
+			t.Errorf("%s(%d, %d) = %d, %v, expected %d, %v", test.name, test.a, test.b, result, ok, test.result, test.ok)
+		}
+	}
+}
+
+func TestAnswer(t *testing.T) {
+	tooBig := new(big.Int).Add(big.NewInt(math.MaxInt64), big.NewInt(1))
+	tests := []struct {
+		value    *big.Int
+		expected string
+		isInt    bool
+	}{
+		{big.NewInt(42), "42", true},
+		{big.NewInt(math.MinInt64), "-9223372036854775808", true},
+		{tooBig, "9223372036854775808", false},
+		{new(big.Int).Neg(tooBig).Sub(new(big.Int).Neg(tooBig), big.NewInt(1)), "-9223372036854775809", false},
+	}
+	for _, test := range tests {
+		answer := Answer(test.value)
+		if _, isInt := answer.(int); isInt != test.isInt || bigString(answer) != test.expected {
+			t.Errorf("Answer(%v) = %v (%T), expected %s", test.value, answer, answer, test.expected)
+		}
+	}
+}
+
+func bigString(answer any) string {
+	switch value := answer.(type) {
+	case int:
+		return big.NewInt(int64(value)).String()
+	case *big.Int:
+		return value.String()
+	}
+	return ""
+}
+
+func TestSum(t *testing.T) {
+	tests := []struct {
+		name     string
+		sum      func() Sum
+		expected string
+		exact    bool
+	}{
+		{"small", func() Sum { s := Sum{}; s.Add(1); s.Add(-3); return s }, "-2", false},
+		{"overflow", func() Sum { s := Sum{}; s.Add(math.MaxInt); s.Add(1); return s }, "9223372036854775808", true},
+		{"overflow and back", func() Sum { s := Sum{}; s.Add(math.MaxInt); s.Add(2); s.Add(-10); return s }, "9223372036854775799", true},
+		{"underflow", func() Sum { s := Sum{}; s.Add(math.MinInt); s.Add(-1); return s }, "-9223372036854775809", true},
+		{"UseBig", func() Sum { s := Sum{}; s.Add(5); s.UseBig(); s.Add(2); return s }, "7", true},
+		{"AddBig", func() Sum { s := Sum{}; s.Add(5); s.AddBig(big.NewInt(3)); return s }, "8", true},
+		{"AddSum of ints", func() Sum { s, o := Sum{}, Sum{}; s.Add(5); o.Add(6); s.AddSum(o); return s }, "11", false},
+		{"AddSum of a big sum", func() Sum {
+			s, o := Sum{}, Sum{}
+			o.Add(math.MaxInt)
+			o.Add(math.MaxInt)
+			s.Add(2)
+			s.AddSum(o)
+			return s
+		}, "18446744073709551616", true},
+	}
+	for _, test := range tests {
+		sum := test.sum()
+		if got := sum.Big().String(); got != test.expected || (sum.exact != nil) != test.exact {
+			t.Errorf("%s: sum %s (exact %v), expected %s (exact %v)", test.name, got, sum.exact != nil, test.expected, test.exact)
+		}
+		if bigString(sum.Value()) != test.expected {
+			t.Errorf("%s: value %v", test.name, sum.Value())
+		}
+	}
+
+	// Big returns a copy, the sum doesn't change along with it
+	sum := Sum{}
+	sum.UseBig()
+	sum.Big().SetInt64(10)
+	if sum.Big().Sign() != 0 {
+		t.Errorf("the sum changed to %v through Big", sum.Big())
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"bta/aoc23/puzzle"
+)
+
+// Error of an API response, parse errors also locate the faulty line
+type apiError struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+	Text    string `json:"text,omitempty"`
+}
+
+type apiResponse struct {
+	Year       int           `json:"year"`
+	Day        int           `json:"day"`
+	Part       int           `json:"part"`
+	Answer     string        `json:"answer,omitempty"`
+	Duration   string        `json:"duration,omitempty"`
+	DurationNs time.Duration `json:"durationNs,omitempty"`
+	Error      *apiError     `json:"error,omitempty"`
+}
+
+type apiServer struct {
+	// Time limit of a request, a shorter one can be asked with ?timeout=
+	timeout time.Duration
+	// Parts are solved in child processes, killed when their request times
+	// out
+	children *childPool
+}
+
+func writeJSON(w http.ResponseWriter, status int, response apiResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("couldn't write the response", "err", err)
+	}
+}
+
+func failure(kind string, err error) *apiError {
+	return &apiError{Kind: kind, Message: err.Error()}
+}
+
+// Routes POST /years/{year}/days/{day}/parts/{part}, and POST
+// /days/{day}/parts/{part} for the days of the latest year
+func (s *apiServer) handleSolve(w http.ResponseWriter, r *http.Request) {
+	response := apiResponse{}
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	year, yearErr := puzzle.LatestYear(), error(nil)
+	if len(segments) == 6 && segments[0] == "years" {
+		year, yearErr = strconv.Atoi(segments[1])
+		segments = segments[2:]
+	}
+
+	if len(segments) != 4 || segments[0] != "days" || segments[2] != "parts" {
+		writeJSON(w, http.StatusNotFound, apiResponse{Error: failure("route", fmt.Errorf("expected POST /years/{year}/days/{day}/parts/{part}"))})
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, apiResponse{Error: failure("route", fmt.Errorf("parts are solved with POST"))})
+		return
+	}
+	dayNumber, dayErr := strconv.Atoi(segments[1])
+	part, partErr := strconv.Atoi(segments[3])
+	response.Year, response.Day, response.Part = year, dayNumber, part
+	if yearErr != nil || dayErr != nil || partErr != nil {
+		response.Error = failure("request", fmt.Errorf("year, day and part must be numbers"))
+		writeJSON(w, http.StatusBadRequest, response)
+		return
+	}
+	day, err := puzzle.Lookup(year, dayNumber)
+	if err != nil {
+		response.Error = failure("request", err)
+		writeJSON(w, http.StatusNotFound, response)
+		return
+	}
+	if _, solved := day.Part(part); !solved {
+		response.Error = failure("request", fmt.Errorf("day %d of %d part %d has no solver", dayNumber, year, part))
+		writeJSON(w, http.StatusNotFound, response)
+		return
+	}
+
+	timeout, opts, err := s.parseQuery(day, r)
+	if err != nil {
+		response.Error = failure("request", err)
+		writeJSON(w, http.StatusBadRequest, response)
+		return
+	}
+	input, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxUploadSize))
+	if err != nil {
+		response.Error = failure("request", err)
+		writeJSON(w, http.StatusRequestEntityTooLarge, response)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+	result, err := s.children.solve(ctx, job{day: day, part: part, input: input, opts: opts})
+	if err != nil {
+		response.Error = failure("timeout", fmt.Errorf("no answer within %v", timeout))
+		writeJSON(w, http.StatusGatewayTimeout, response)
+		return
+	}
+	response.Duration, response.DurationNs = result.Duration.String(), result.Duration
+	if result.Err != nil {
+		response.Error = failure("solver", result.Err)
+		var parseErr *puzzle.ParseError
+		if errors.As(result.Err, &parseErr) {
+			response.Error.Kind = "parse"
+			response.Error.Line, response.Error.Text = parseErr.Line, parseErr.Text
+			writeJSON(w, http.StatusBadRequest, response)
+			return
+		}
+		writeJSON(w, http.StatusUnprocessableEntity, response)
+		return
+	}
+	response.Answer = result.Answer
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Query parameters are day options, along with an optional timeout
+func (s *apiServer) parseQuery(day puzzle.Day, r *http.Request) (time.Duration, puzzle.Options, error) {
+	timeout := s.timeout
+	values := map[string]string{}
+
+	for name, value := range r.URL.Query() {
+		if name != "timeout" {
+			values[name] = value[len(value)-1]
+			continue
+		}
+		asked, err := time.ParseDuration(value[len(value)-1])
+		if err != nil {
+			return 0, nil, fmt.Errorf("timeout must be a duration, eg: 10s")
+		}
+		timeout = min(asked, s.timeout)
+	}
+	opts, err := day.ParseOptions(values)
+	return timeout, opts, err
+}
+
+func apiCommand(args []string) error {
+	fs := flag.NewFlagSet("api", flag.ContinueOnError)
+	address := fs.String("addr", "localhost:8024", "address the API listens on")
+	timeout := fs.Duration("timeout", 30*time.Second, "longest time a request may take, requests can ask for less with ?timeout=")
+	workers := fs.Int("workers", runtime.NumCPU(), "amount of requests solved at once, each in its own process")
+	memory := fs.Int("memory", 4096, "address space limit of a solving process in MiB, none when 0")
+	logs := logFlags{}
+	logs.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	logs.setup()
+
+	s := &apiServer{timeout: *timeout, children: newChildPool(*memory, *workers)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/years/", s.handleSolve)
+	mux.HandleFunc("/days/", s.handleSolve)
+
+	slog.Info("API listening", "url", "http://"+*address)
+	return http.ListenAndServe(*address, mux)
+}
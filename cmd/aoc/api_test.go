@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Isolated parts are solved by the test binary itself, started as "child"
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == "child" {
+		if err := childCommand(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func post(t *testing.T, s *apiServer, path, input string) (int, apiResponse) {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	s.handleSolve(recorder, httptest.NewRequest(http.MethodPost, path, strings.NewReader(input)))
+	response := apiResponse{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+	return recorder.Code, response
+}
+
+// Day 16 grid whose part 2, lighting it from every side, takes minutes
+func slowGrid() string {
+	random := rand.New(rand.NewSource(1))
+	rows := make([]string, 0, 300)
+	for len(rows) < cap(rows) {
+		row := make([]byte, cap(rows))
+		for x := range row {
+			row[x] = "....../\\|-"[random.Intn(10)]
+		}
+		rows = append(rows, string(row))
+	}
+	return strings.Join(rows, "\n")
+}
+
+func TestAPI(t *testing.T) {
+	s := &apiServer{timeout: 10 * time.Second, children: newChildPool(0, 1)}
+
+	if code, response := post(t, s, "/years/2023/days/17/parts/1", "241\n3x2\n"); code != http.StatusBadRequest || response.Error == nil || response.Error.Kind != "parse" || response.Error.Line != 2 {
+		t.Errorf("malformed grid: %d %+v", code, response.Error)
+	}
+
+	if code, response := post(t, s, "/days/2/parts/1", "Game 1: 3 red\nGame 2: 3 purple"); code != http.StatusBadRequest || response.Error == nil || response.Error.Line != 2 {
+		t.Errorf("unknown color: %d %+v", code, response.Error)
+	}
+	if code, response := post(t, s, "/days/8/parts/1", "LR\n\nAAA = (BBB, QQQ)\nBBB = (AAA, ZZZ)"); code != http.StatusBadRequest || response.Error == nil || response.Error.Line != 3 {
+		t.Errorf("unknown node: %d %+v", code, response.Error)
+	}
+
+	// The solver must be killed for the only slot to be free again
+	for attempt := 0; attempt < 2; attempt++ {
+		if code, response := post(t, s, "/days/16/parts/2?timeout=300ms", slowGrid()); code != http.StatusGatewayTimeout {
+			t.Errorf("endless solver: %d %+v", code, response)
+		}
+	}
+	if code, response := post(t, s, "/days/8/parts/1", "L\n\nAAA = (ZZZ, ZZZ)\nZZZ = (ZZZ, ZZZ)"); code != http.StatusOK || response.Answer != "1" {
+		t.Errorf("after the timeouts: %d %+v", code, response)
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"bta/aoc23/puzzle"
+)
+
+const (
+	cacheDirname = "cache"
+)
+
+// Answer of a part, cached under the hash of everything it was computed from
+type cacheEntry struct {
+	Year      int           `json:"year"`
+	Day       int           `json:"day"`
+	Part      int           `json:"part"`
+	Options   string        `json:"options"`
+	InputHash string        `json:"inputHash"`
+	Version   string        `json:"version"`
+	Answer    string        `json:"answer"`
+	Duration  time.Duration `json:"duration"`
+	CachedAt  time.Time     `json:"cachedAt"`
+}
+
+type answerCache struct {
+	dir     string
+	version string
+}
+
+var (
+	buildVersionOnce sync.Once
+	buildVersion     string
+	buildVersionErr  error
+)
+
+// The solver version is the hash of the aoc executable: go builds are
+// reproducible, so it only changes with the code of a solver (or of
+// anything it uses) and a code change always forces a real recompute.
+func solverVersion() (string, error) {
+	buildVersionOnce.Do(func() {
+		executable, err := os.Executable()
+		if err != nil {
+			buildVersionErr = err
+			return
+		}
+		file, err := os.Open(executable)
+		if err != nil {
+			buildVersionErr = err
+			return
+		}
+		defer file.Close()
+
+		hash := sha256.New()
+		if _, err := io.Copy(hash, file); err != nil {
+			buildVersionErr = err
+			return
+		}
+		buildVersion = hex.EncodeToString(hash.Sum(nil))[:16]
+	})
+	return buildVersion, buildVersionErr
+}
+
+func openCache() (*answerCache, error) {
+	version, err := solverVersion()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't identify the solvers version: %v", err)
+	}
+	return &answerCache{
+		dir:     filepath.Join(puzzle.Root, stateDir, cacheDirname),
+		version: version,
+	}, nil
+}
+
+// Options written as sorted name=value pairs
+func formatOptions(opts puzzle.Options) string {
+	pairs := make([]string, 0, len(opts))
+
+	for name, value := range opts {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", name, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (c *answerCache) key(year, day, part int, opts puzzle.Options, inputHash string) cacheEntry {
+	return cacheEntry{
+		Year:      year,
+		Day:       day,
+		Part:      part,
+		Options:   formatOptions(opts),
+		InputHash: inputHash,
+		Version:   c.version,
+	}
+}
+
+func (c *answerCache) path(key cacheEntry) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%d/%d/%d/%s/%s/%s", key.Year, key.Day, key.Part, key.Options, key.InputHash, key.Version)))
+	return filepath.Join(c.dir, hex.EncodeToString(hash[:])+".json")
+}
+
+func (c *answerCache) get(key cacheEntry) (puzzle.Result, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return puzzle.Result{}, false
+	}
+	entry := cacheEntry{}
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Version != c.version {
+		return puzzle.Result{}, false
+	}
+	return puzzle.Result{Year: entry.Year, Day: entry.Day, Part: entry.Part, Answer: entry.Answer, Duration: entry.Duration}, true
+}
+
+func (c *answerCache) put(key cacheEntry, result puzzle.Result) error {
+	entry := key
+	entry.Answer = result.Answer
+	entry.Duration = result.Duration
+	entry.CachedAt = time.Now()
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path(key), data, 0o644)
+}
+
+// Removes the answers of other solver versions, or every answer
+func (c *answerCache) prune(all bool) (int, error) {
+	files, err := os.ReadDir(c.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	removed := 0
+
+	for _, file := range files {
+		path := filepath.Join(c.dir, file.Name())
+		if !all {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return removed, err
+			}
+			entry := cacheEntry{}
+			if json.Unmarshal(data, &entry) == nil && entry.Version == c.version {
+				continue
+			}
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+func cacheCommand(args []string) error {
+	if len(args) == 0 || args[0] != "prune" {
+		fmt.Fprintf(os.Stderr, "usage: aoc cache prune [-all]\n")
+		return flag.ErrHelp
+	}
+	fs := flag.NewFlagSet("cache prune", flag.ContinueOnError)
+	all := fs.Bool("all", false, "remove every cached answer, not only the ones of other solver versions")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	cache, err := openCache()
+	if err != nil {
+		return err
+	}
+	removed, err := cache.prune(*all)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("removed %d cached answers\n", removed)
+	return nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"plugin"
+	"time"
+
+	"bta/aoc23/puzzle"
+)
+
+// Loads the solvers of a plugin built with go build -buildmode=plugin, its
+// main package exports the day it solves and its parts as puzzle.Solver
+// functions, either part can be left out. The year is optional, year is
+// used when the plugin has none:
+//
+//	var Year = 2023
+//	var Day = 12
+//	func Part1(input []byte, opts puzzle.Options) (any, error)
+//	func Part2(input []byte, opts puzzle.Options) (any, error)
+//
+// The returned day has the options of the built-in one.
+func loadPlugin(path string, year int) (puzzle.Day, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return puzzle.Day{}, err
+	}
+	symbol, err := p.Lookup("Day")
+	if err != nil {
+		return puzzle.Day{}, err
+	}
+	dayNumber, ok := symbol.(*int)
+	if !ok {
+		return puzzle.Day{}, fmt.Errorf("plugin '%s': Day must be an int, got %T", path, symbol)
+	}
+	if symbol, err := p.Lookup("Year"); err == nil {
+		pluginYear, ok := symbol.(*int)
+		if !ok {
+			return puzzle.Day{}, fmt.Errorf("plugin '%s': Year must be an int, got %T", path, symbol)
+		}
+		year = *pluginYear
+	}
+	builtin, err := puzzle.Lookup(year, *dayNumber)
+	if err != nil {
+		return puzzle.Day{}, err
+	}
+
+	day := puzzle.Day{Year: builtin.Year, Number: builtin.Number, Title: builtin.Title, Options: builtin.Options}
+	for partNumber := 1; partNumber <= len(day.Parts); partNumber++ {
+		symbol, err := p.Lookup(fmt.Sprintf("Part%d", partNumber))
+		if err != nil {
+			continue
+		}
+		solve, ok := symbol.(func([]byte, puzzle.Options) (any, error))
+		if !ok {
+			return puzzle.Day{}, fmt.Errorf("plugin '%s': Part%d must be a puzzle.Solver, got %T", path, partNumber, symbol)
+		}
+		day.Parts[partNumber-1].Solve = solve
+	}
+	return day, nil
+}
+
+// Answers and run times of a solver over the runs of a comparison
+type contender struct {
+	day       puzzle.Day
+	answers   []string
+	durations []time.Duration
+	err       error
+}
+
+func (c *contender) run(part int, input []byte, opts puzzle.Options) {
+	result := c.day.Run(part, input, opts)
+	if result.Err != nil && c.err == nil {
+		c.err = result.Err
+	}
+	c.answers = append(c.answers, result.Answer)
+	c.durations = append(c.durations, result.Duration)
+}
+
+// Reports whether every run gave the same answer
+func (c *contender) stable() bool {
+	for _, answer := range c.answers {
+		if answer != c.answers[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *contender) median() time.Duration {
+	return medianDuration(c.durations)
+}
+
+func compareCommand(args []string) error {
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	pluginPath := fs.String("plugin", "", "solver plugin (.so) to compare to the built-in solver of its day")
+	part := fs.Int("part", 0, "part to compare (1 or 2), every part of the plugin when 0")
+	runs := fs.Int("runs", 5, "times each solver is run, the median run time is compared")
+	inputs := inputFlags{}
+	inputs.register(fs)
+	logs := logFlags{}
+	logs.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	logs.setup()
+	if *pluginPath == "" {
+		return fmt.Errorf("-plugin is required")
+	}
+	if *runs < 1 {
+		return fmt.Errorf("-runs must be at least 1")
+	}
+
+	pluginDay, err := loadPlugin(*pluginPath, inputs.year)
+	if err != nil {
+		return err
+	}
+	builtin, err := puzzle.Lookup(pluginDay.Year, pluginDay.Number)
+	if err != nil {
+		return err
+	}
+	input, opts, err := inputs.load(builtin)
+	if err != nil {
+		return err
+	}
+
+	disagreements := 0
+	for partNumber := 1; partNumber <= len(pluginDay.Parts); partNumber++ {
+		_, pluginSolved := pluginDay.Part(partNumber)
+		if !pluginSolved || (*part != 0 && partNumber != *part) {
+			continue
+		}
+		mine, theirs := &contender{day: builtin}, &contender{day: pluginDay}
+
+		// Interleaved, so that a slower machine state is shared by both
+		for run := 0; run < *runs; run++ {
+			mine.run(partNumber, input, opts)
+			theirs.run(partNumber, input, opts)
+		}
+
+		label := fmt.Sprintf("day %d part %d", builtin.Number, partNumber)
+		switch {
+		case mine.err != nil || theirs.err != nil:
+			disagreements++
+			fmt.Printf("%s: built-in error: %v, plugin error: %v\n", label, mine.err, theirs.err)
+		case !mine.stable() || !theirs.stable():
+			disagreements++
+			fmt.Printf("%s: answers changed between runs, built-in %v, plugin %v\n", label, mine.answers, theirs.answers)
+		case mine.answers[0] != theirs.answers[0]:
+			disagreements++
+			fmt.Printf("%s: DISAGREE, built-in %s, plugin %s\n", label, mine.answers[0], theirs.answers[0])
+		default:
+			fmt.Printf("%s: agree on %s\n", label, mine.answers[0])
+		}
+
+		mineTime, theirsTime := mine.median(), theirs.median()
+		fmt.Printf("  built-in %12v\n  plugin   %12v", mineTime, theirsTime)
+		if mineTime > 0 && theirsTime > 0 {
+			if ratio := float64(theirsTime) / float64(mineTime); ratio >= 1 {
+				fmt.Printf("  (%.2fx slower)", ratio)
+			} else {
+				fmt.Printf("  (%.2fx faster)", 1/ratio)
+			}
+		}
+		fmt.Printf("  median of %d runs\n", *runs)
+	}
+	if disagreements > 0 {
+		return fmt.Errorf("the plugin disagrees on %d parts", disagreements)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package main
+
+// Every day registers its solvers from init
+import (
+	_ "bta/aoc23/2023/day01"
+	_ "bta/aoc23/2023/day02"
+	_ "bta/aoc23/2023/day03"
+	_ "bta/aoc23/2023/day04"
+	_ "bta/aoc23/2023/day05"
+	_ "bta/aoc23/2023/day06"
+	_ "bta/aoc23/2023/day07"
+	_ "bta/aoc23/2023/day08"
+	_ "bta/aoc23/2023/day09"
+	_ "bta/aoc23/2023/day10"
+	_ "bta/aoc23/2023/day11"
+	_ "bta/aoc23/2023/day12"
+	_ "bta/aoc23/2023/day13"
+	_ "bta/aoc23/2023/day14"
+	_ "bta/aoc23/2023/day15"
+	_ "bta/aoc23/2023/day16"
+	_ "bta/aoc23/2023/day17"
+	_ "bta/aoc23/2023/day18"
+)
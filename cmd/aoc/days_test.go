@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"bta/aoc23/puzzle"
+)
+
+// The examples of every day, read from the embedded instructions
+func TestExamples(t *testing.T) {
+	for _, day := range puzzle.Days() {
+		examples, err := day.Examples()
+		if err != nil {
+			t.Errorf("%d day %d: %v", day.Year, day.Number, err)
+			continue
+		}
+		for index, part := range day.Parts {
+			for _, example := range part.Examples {
+				t.Run(fmt.Sprintf("%d/day%02d/part%d/block%d", day.Year, day.Number, index+1, example.Block), func(t *testing.T) {
+					if example.Block >= len(examples) {
+						t.Fatalf("the instructions have %d example blocks", len(examples))
+					}
+					result := day.Run(index+1, []byte(examples[example.Block]), day.DefaultOptions())
+					if result.Err != nil {
+						t.Fatal(result.Err)
+					}
+					if result.Answer != example.Answer {
+						t.Errorf("answer %s, expected %s", result.Answer, example.Answer)
+					}
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+
+	"bta/aoc23/puzzle"
+)
+
+// Distinct output of the runs of a day, in the order they first showed up
+type runVariant struct {
+	output string
+	runs   int
+}
+
+// Output of aoc run in a fresh process: each process gets its own map hash
+// seed, so an order-dependent solver shows up as a changing output
+func runFresh(executable string, args []string) (string, error) {
+	cmd := exec.Command(executable, append([]string{"run"}, args...)...)
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		// Failed parts are part of the output, the reason is on the last
+		// line of stderr
+		lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+		fmt.Fprintf(&stdout, "%v: %s\n", exitErr, lines[len(lines)-1])
+	case err != nil:
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// Lines of output missing from base (+) or only in base (-)
+func diffLines(base, output string) []string {
+	baseLines, lines := strings.Split(base, "\n"), strings.Split(output, "\n")
+	diff := make([]string, 0)
+
+	for _, line := range baseLines {
+		if !slices.Contains(lines, line) {
+			diff = append(diff, "- "+line)
+		}
+	}
+	for _, line := range lines {
+		if !slices.Contains(baseLines, line) {
+			diff = append(diff, "+ "+line)
+		}
+	}
+	return diff
+}
+
+func checkDeterminism(executable string, day puzzle.Day, part, runs int, inputArgs []string) (bool, error) {
+	args := append([]string{"-day", strconv.Itoa(day.Number), "-part", strconv.Itoa(part), "-no-cache", "-explain", "-timings=false", "-q"}, inputArgs...)
+	variants := make([]runVariant, 0, 1)
+
+	for run := 0; run < runs; run++ {
+		output, err := runFresh(executable, args)
+		if err != nil {
+			return false, err
+		}
+		index := slices.IndexFunc(variants, func(v runVariant) bool { return v.output == output })
+		if index < 0 {
+			variants = append(variants, runVariant{output: output})
+			index = len(variants) - 1
+		}
+		variants[index].runs++
+	}
+
+	if len(variants) == 1 {
+		fmt.Printf("day %d: %d runs, same output\n", day.Number, runs)
+		return true, nil
+	}
+	fmt.Printf("day %d: %d runs, %d distinct outputs\n", day.Number, runs, len(variants))
+	fmt.Printf("  output 1 (%d runs):\n", variants[0].runs)
+	for _, line := range strings.Split(strings.TrimSpace(variants[0].output), "\n") {
+		fmt.Printf("    %s\n", line)
+	}
+	for index, variant := range variants[1:] {
+		fmt.Printf("  output %d (%d runs), against output 1:\n", index+2, variant.runs)
+		for _, line := range diffLines(strings.TrimSpace(variants[0].output), strings.TrimSpace(variant.output)) {
+			fmt.Printf("    %s\n", line)
+		}
+	}
+	return false, nil
+}
+
+func determinismCommand(args []string) error {
+	fs := flag.NewFlagSet("determinism", flag.ContinueOnError)
+	dayNumber := fs.Int("day", 0, "day to check, every day when 0")
+	part := fs.Int("part", 0, "part to check (1 or 2), both when 0")
+	runs := fs.Int("runs", 50, "times each day is solved, each time in a new aoc process")
+	inputs := inputFlags{}
+	inputs.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *runs < 2 {
+		return fmt.Errorf("-runs must be at least 2")
+	}
+	inputArgs, err := inputs.args()
+	if err != nil {
+		return err
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	days := puzzle.YearDays(inputs.year)
+	if *dayNumber != 0 {
+		day, err := puzzle.Lookup(inputs.year, *dayNumber)
+		if err != nil {
+			return err
+		}
+		days = []puzzle.Day{day}
+	}
+	varying := 0
+	for _, day := range days {
+		same, err := checkDeterminism(executable, day, *part, *runs, inputArgs)
+		if err != nil {
+			return err
+		}
+		if !same {
+			varying++
+		}
+	}
+	if varying > 0 {
+		return fmt.Errorf("%d of %d days gave different outputs", varying, len(days))
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"bta/aoc23/puzzle"
+)
+
+// Days whose input is handled by aoc inputs, every registered day of the
+// year when 0, of every year when the year is 0 too. A day without a year is
+// one of the latest year.
+func inputDays(year, dayNumber int) ([]puzzle.Day, error) {
+	switch {
+	case year == 0 && dayNumber == 0:
+		return puzzle.Days(), nil
+	case year == 0:
+		year = puzzle.LatestYear()
+	case dayNumber == 0:
+		return puzzle.YearDays(year), nil
+	}
+	day, err := puzzle.Lookup(year, dayNumber)
+	if err != nil {
+		return nil, err
+	}
+	return []puzzle.Day{day}, nil
+}
+
+// Encrypts the plaintext inputs next to them, the plaintext is only removed
+// once its sealed version can be read back. Each sealing takes a fresh nonce,
+// so a sealed input which already reads back as its plaintext is kept rather
+// than rewritten with other bytes.
+func sealInputs(days []puzzle.Day, key []byte, remove bool) error {
+	for _, day := range days {
+		path := day.InputPath()
+		plaintext, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		sealed, err := os.ReadFile(path + puzzle.SealedSuffix)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if existing, err := puzzle.Unseal(sealed, key); err == nil && bytes.Equal(existing, plaintext) {
+			fmt.Printf("day %d: %s already sealed\n", day.Number, path)
+		} else {
+			if sealed, err = puzzle.Seal(plaintext, key); err != nil {
+				return err
+			}
+			if err := os.WriteFile(path+puzzle.SealedSuffix, sealed, 0o644); err != nil {
+				return err
+			}
+			fmt.Printf("day %d: sealed %s\n", day.Number, path+puzzle.SealedSuffix)
+		}
+		if !remove {
+			continue
+		}
+		if _, err := puzzle.Unseal(sealed, key); err != nil {
+			return fmt.Errorf("day %d: %s kept, %v", day.Number, path, err)
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Writes the plaintext of the sealed inputs, existing plaintexts are kept
+func unsealInputs(days []puzzle.Day, key []byte) error {
+	for _, day := range days {
+		path := day.InputPath()
+		sealed, err := os.ReadFile(path + puzzle.SealedSuffix)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if _, err := os.Stat(path); err == nil {
+			fmt.Printf("day %d: %s already there\n", day.Number, path)
+			continue
+		}
+
+		plaintext, err := puzzle.Unseal(sealed, key)
+		if err != nil {
+			return fmt.Errorf("day %d: %v", day.Number, err)
+		}
+		if err := os.WriteFile(path, plaintext, 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("day %d: unsealed %s\n", day.Number, path)
+	}
+	return nil
+}
+
+func inputsCommand(args []string) error {
+	if len(args) == 0 || (args[0] != "key" && args[0] != "seal" && args[0] != "unseal") {
+		fmt.Fprintf(os.Stderr, "usage: aoc inputs key | seal [-year Y] [-day N] [-remove] | unseal [-year Y] [-day N]\n")
+		return flag.ErrHelp
+	}
+	if args[0] == "key" {
+		key, err := puzzle.NewInputKey()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("export %s=%s\n", puzzle.KeyEnv, key)
+		return nil
+	}
+
+	fs := flag.NewFlagSet("inputs "+args[0], flag.ContinueOnError)
+	year := registerYear(fs, true)
+	dayNumber := fs.Int("day", 0, "day whose input is handled, every day when 0")
+	remove := false
+	if args[0] == "seal" {
+		fs.BoolVar(&remove, "remove", false, "remove the plaintext inputs once sealed")
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	days, err := inputDays(*year, *dayNumber)
+	if err != nil {
+		return err
+	}
+	key, err := puzzle.InputKey()
+	if err != nil {
+		return err
+	}
+	if args[0] == "seal" {
+		return sealInputs(days, key, remove)
+	}
+	return unsealInputs(days, key)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bta/aoc23/puzzle"
+)
+
+// The real inputs are the ones the known answers were accepted on, sealed
+// ones included. Without the key, or without the inputs, they are skipped.
+// Solving them all takes a while, -short only checks that they didn't change.
+func TestRealInputs(t *testing.T) {
+	root := puzzle.Root
+	t.Cleanup(func() { puzzle.Root = root })
+	puzzle.Root = filepath.Join("..", "..")
+	known, err := loadKnownAnswers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, answer := range known.Answers {
+		if answer.Source != "input" {
+			continue
+		}
+		answer := answer
+		t.Run(fmt.Sprintf("%d/day%02d/part%d", answer.Year, answer.Day, answer.Part), func(t *testing.T) {
+			t.Parallel()
+			j, err := knownJob(answer)
+			if errors.Is(err, puzzle.ErrNoKey) || errors.Is(err, os.ErrNotExist) {
+				t.Skip(err)
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			hash, err := j.inputHash()
+			if errors.Is(err, puzzle.ErrNoKey) || errors.Is(err, os.ErrNotExist) {
+				t.Skip(err)
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if hash != answer.InputHash {
+				t.Fatalf("the input changed since %s was accepted", answer.Answer)
+			}
+			if testing.Short() {
+				return
+			}
+
+			result := j.solve()
+			if result.Err != nil {
+				t.Fatal(result.Err)
+			}
+			if result.Answer != answer.Answer {
+				t.Errorf("answer %s, expected %s", result.Answer, answer.Answer)
+			}
+		})
+	}
+}
+
+func TestSealInputs(t *testing.T) {
+	encoded, err := puzzle.NewInputKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, _ := hex.DecodeString(encoded)
+	root := puzzle.Root
+	t.Cleanup(func() { puzzle.Root = root })
+	puzzle.Root = t.TempDir()
+
+	day, err := puzzle.Lookup(2023, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(day.InputPath()), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(day.InputPath(), []byte("1abc2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sealedPath := day.InputPath() + puzzle.SealedSuffix
+
+	if err := sealInputs([]puzzle.Day{day}, key, false); err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := os.ReadFile(sealedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Sealing the same input again leaves the sealed file as it is
+	if err := sealInputs([]puzzle.Day{day}, key, false); err != nil {
+		t.Fatal(err)
+	}
+	if again, err := os.ReadFile(sealedPath); err != nil || string(again) != string(sealed) {
+		t.Errorf("unchanged input sealed again: %v", err)
+	}
+
+	if err := os.WriteFile(day.InputPath(), []byte("pqr3stu8vwx\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := sealInputs([]puzzle.Day{day}, key, true); err != nil {
+		t.Fatal(err)
+	}
+	if sealed, err = os.ReadFile(sealedPath); err != nil {
+		t.Fatal(err)
+	}
+	if plaintext, err := puzzle.Unseal(sealed, key); err != nil || string(plaintext) != "pqr3stu8vwx\n" {
+		t.Errorf("changed input sealed as %q, %v", plaintext, err)
+	}
+	if _, err := os.Stat(day.InputPath()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("plaintext kept after -remove: %v", err)
+	}
+}
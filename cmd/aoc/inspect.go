@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+
+	"bta/aoc23/puzzle"
+)
+
+// Facts every input has, before the ones of the day's inspector
+func inputFacts(input []byte) []puzzle.Fact {
+	lines := 0
+	if len(input) > 0 {
+		lines = bytes.Count(input, []byte("\n")) + 1
+	}
+	return []puzzle.Fact{
+		{Name: "bytes", Value: len(input)},
+		{Name: "lines", Value: lines},
+	}
+}
+
+func inspectDay(day puzzle.Day, inputs *inputFlags) error {
+	input, opts, err := inputs.load(day)
+	if err != nil {
+		return err
+	}
+	facts := inputFacts(input)
+	if day.Inspect != nil {
+		dayFacts, err := day.Inspect(input, opts)
+		if err != nil {
+			return fmt.Errorf("day %d: %w", day.Number, err)
+		}
+		facts = append(facts, dayFacts...)
+	}
+
+	fmt.Printf("--- Day %d: %s ---\n", day.Number, day.Title)
+	for _, fact := range facts {
+		fmt.Printf("%-24s %v\n", fact.Name, fact.Value)
+	}
+	if day.Inspect == nil {
+		fmt.Printf("(day %d has no inspector)\n", day.Number)
+	}
+	return nil
+}
+
+func inspectCommand(args []string) error {
+	fs := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	dayNumber := fs.Int("day", 0, "day whose input is inspected, every day when 0")
+	inputs := inputFlags{}
+	inputs.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	days := puzzle.YearDays(inputs.year)
+	if *dayNumber != 0 {
+		day, err := puzzle.Lookup(inputs.year, *dayNumber)
+		if err != nil {
+			return err
+		}
+		days = []puzzle.Day{day}
+	}
+	for index, day := range days {
+		if index > 0 {
+			fmt.Println()
+		}
+		if err := inspectDay(day, &inputs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
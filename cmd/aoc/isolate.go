@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"bta/aoc23/puzzle"
+)
+
+// Budget of the child process an isolated part is solved in (run -isolate),
+// a limit is left out when 0
+type isolation struct {
+	cpu time.Duration
+	// Address space, in MiB
+	memory int
+}
+
+// Answer the child writes on stdout
+type childResult struct {
+	Answer     string        `json:"answer,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	DurationNs time.Duration `json:"durationNs"`
+	// Location of the error when it is a puzzle.ParseError
+	Line int    `json:"line,omitempty"`
+	Text string `json:"text,omitempty"`
+}
+
+// Keeps what a child wrote on stderr: its last log record, which tells how
+// far the solver got, and the first lines that aren't records (a Go runtime
+// error)
+type childLog struct {
+	pending    []byte
+	lastRecord map[string]any
+	other      []string
+}
+
+func (l *childLog) Write(p []byte) (int, error) {
+	l.pending = append(l.pending, p...)
+	for {
+		index := bytes.IndexByte(l.pending, '\n')
+		if index < 0 {
+			return len(p), nil
+		}
+		line := l.pending[:index]
+		record := map[string]any{}
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.UseNumber()
+		if decoder.Decode(&record) == nil {
+			l.lastRecord = record
+		} else if len(l.other) < 20 {
+			l.other = append(l.other, string(line))
+		}
+		l.pending = l.pending[index+1:]
+	}
+}
+
+// The last record as "message key=value ...", "" when the child logged
+// nothing
+func (l *childLog) progress() string {
+	if l.lastRecord == nil {
+		return ""
+	}
+	keys := make([]string, 0, len(l.lastRecord))
+	for key := range l.lastRecord {
+		if key != slog.TimeKey && key != slog.LevelKey && key != slog.MessageKey {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	progress := fmt.Sprint(l.lastRecord[slog.MessageKey])
+	for _, key := range keys {
+		progress += fmt.Sprintf(" %s=%v", key, l.lastRecord[key])
+	}
+	return progress
+}
+
+func (l *childLog) outOfMemory() bool {
+	for _, line := range l.other {
+		if strings.Contains(line, "out of memory") || strings.Contains(line, "cannot allocate memory") {
+			return true
+		}
+	}
+	return false
+}
+
+// Solves the job in a child aoc process, the input is given on its stdin.
+// The child is killed when ctx is done.
+func (iso *isolation) solve(ctx context.Context, j job) puzzle.Result {
+	result := puzzle.Result{Year: j.day.Year, Day: j.day.Number, Part: j.part}
+	executable, err := os.Executable()
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	args := []string{
+		"child", "-year", strconv.Itoa(j.day.Year), "-day", strconv.Itoa(j.day.Number), "-part", strconv.Itoa(j.part),
+		"-cpu", iso.cpu.String(), "-memory", strconv.Itoa(iso.memory),
+	}
+	for name, value := range j.opts {
+		args = append(args, "-opt", fmt.Sprintf("%s=%d", name, value))
+	}
+
+	cmd := exec.CommandContext(ctx, executable, args...)
+	if j.path == "" {
+		cmd.Stdin = bytes.NewReader(j.input)
+	} else {
+		file, err := puzzle.OpenInput(j.path)
+		if err != nil {
+			result.Err = fmt.Errorf("couldn't open input file '%s'\n%v", j.path, err)
+			return result
+		}
+		defer file.Close()
+		cmd.Stdin = file
+	}
+	stdout, log := bytes.Buffer{}, &childLog{}
+	cmd.Stdout, cmd.Stderr = &stdout, log
+
+	start := time.Now()
+	runErr := cmd.Run()
+	result.Duration = time.Since(start)
+	state := cmd.ProcessState
+
+	switch {
+	case runErr == nil:
+		response := childResult{}
+		if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+			result.Err = fmt.Errorf("unreadable child answer: %v", err)
+			return result
+		}
+		result.Answer, result.Duration = response.Answer, response.DurationNs
+		if response.Line > 0 {
+			result.Err = &puzzle.ParseError{Line: response.Line, Text: response.Text, Err: errors.New(response.Error)}
+		} else if response.Error != "" {
+			result.Err = errors.New(response.Error)
+		}
+		return result
+	case ctx.Err() != nil:
+		result.Err = fmt.Errorf("child killed: %w", ctx.Err())
+		return result
+	case state == nil:
+		result.Err = runErr
+		return result
+	case iso.cpu > 0 && !state.Exited() && state.UserTime()+state.SystemTime() >= iso.cpu-100*time.Millisecond:
+		result.Err = fmt.Errorf("exceeded budget: %v of CPU time", iso.cpu)
+	case iso.memory > 0 && log.outOfMemory():
+		result.Err = fmt.Errorf("exceeded budget: %d MiB of memory", iso.memory)
+	case len(log.other) > 0:
+		result.Err = fmt.Errorf("child failed, %v: %s", runErr, log.other[0])
+	default:
+		result.Err = fmt.Errorf("child failed, %v", runErr)
+	}
+	if progress := log.progress(); progress != "" {
+		result.Err = fmt.Errorf("%v, last progress: %s", result.Err, progress)
+	}
+	return result
+}
+
+// Isolated children shared by the requests of a server, at most cap(slots)
+// of them solve at once
+type childPool struct {
+	isolation isolation
+	slots     chan struct{}
+}
+
+func newChildPool(memory, workers int) *childPool {
+	return &childPool{isolation: isolation{memory: memory}, slots: make(chan struct{}, max(workers, 1))}
+}
+
+// Solves the job in a child process once a slot is free, the child is killed
+// when ctx is done
+func (p *childPool) solve(ctx context.Context, j job) (puzzle.Result, error) {
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return puzzle.Result{}, ctx.Err()
+	}
+	defer func() { <-p.slots }()
+
+	result := p.isolation.solve(ctx, j)
+	if ctx.Err() != nil {
+		slog.Warn("solver timed out, its process was killed", "year", j.day.Year, "day", j.day.Number, "part", j.part)
+		return puzzle.Result{}, ctx.Err()
+	}
+	return result, nil
+}
+
+// Solves a part in the limits it is given, reading the input on stdin. It is
+// the process started for each part by run -isolate.
+func childCommand(args []string) error {
+	fs := flag.NewFlagSet("child", flag.ContinueOnError)
+	year := fs.Int("year", 0, "event year of the day")
+	dayNumber := fs.Int("day", 0, "day to solve")
+	part := fs.Int("part", 0, "part to solve")
+	cpu := fs.Duration("cpu", 0, "CPU time limit, none when 0")
+	memory := fs.Int("memory", 0, "address space limit in MiB, none when 0")
+	values := optionFlags{}
+	fs.Var(values, "opt", "day option as name=value, can be repeated")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := setLimits(*cpu, *memory); err != nil {
+		return err
+	}
+	// Every record is progress the parent may report
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	day, err := puzzle.Lookup(*year, *dayNumber)
+	if err != nil {
+		return err
+	}
+	opts, err := day.ParseOptions(values)
+	if err != nil {
+		return err
+	}
+	result := day.RunStream(*part, os.Stdin, opts)
+	response := childResult{Answer: result.Answer, DurationNs: result.Duration}
+	var parseErr *puzzle.ParseError
+	if errors.As(result.Err, &parseErr) {
+		response.Error, response.Line, response.Text = parseErr.Err.Error(), parseErr.Line, parseErr.Text
+	} else if result.Err != nil {
+		response.Error = result.Err.Error()
+	}
+	return json.NewEncoder(os.Stdout).Encode(response)
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"bta/aoc23/puzzle"
+	"bta/aoc23/workpool"
+)
+
+const (
+	// Committed with the code, unlike the .aoc state
+	knownAnswersFilename = "answers.json"
+	knownAnswersFormat   = 1
+)
+
+// Accepted answer of a part on a given input, with the options changed
+// from their default (see knownOptions)
+type knownAnswer struct {
+	Year      int    `json:"year"`
+	Day       int    `json:"day"`
+	Part      int    `json:"part"`
+	Source    string `json:"source"`
+	Options   string `json:"options"`
+	InputHash string `json:"inputHash"`
+	Answer    string `json:"answer"`
+}
+
+type knownAnswers struct {
+	Format  int           `json:"format"`
+	Answers []knownAnswer `json:"answers"`
+}
+
+func knownAnswersPath() string {
+	return filepath.Join(puzzle.Root, knownAnswersFilename)
+}
+
+func loadKnownAnswers() (*knownAnswers, error) {
+	file, err := os.ReadFile(knownAnswersPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return &knownAnswers{Format: knownAnswersFormat}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	known := &knownAnswers{}
+	if err := json.Unmarshal(file, known); err != nil {
+		return nil, fmt.Errorf("couldn't parse %s: %v", knownAnswersPath(), err)
+	}
+	if known.Format != knownAnswersFormat {
+		return nil, fmt.Errorf("%s has format %d, this aoc reads format %d", knownAnswersPath(), known.Format, knownAnswersFormat)
+	}
+	return known, nil
+}
+
+func (k *knownAnswers) save() error {
+	sort.Slice(k.Answers, func(i, j int) bool {
+		a, b := k.Answers[i], k.Answers[j]
+		if a.Year != b.Year {
+			return a.Year < b.Year
+		}
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		if a.Part != b.Part {
+			return a.Part < b.Part
+		}
+		return a.Source < b.Source
+	})
+	data, err := json.MarshalIndent(k, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(knownAnswersPath(), append(data, '\n'), 0o644)
+}
+
+// Answers are only keyed by the options changed from their default, so that
+// adding an option to a day keeps the answers accepted before
+func knownOptions(day puzzle.Day, opts puzzle.Options) string {
+	changed := puzzle.Options{}
+
+	for name, value := range opts {
+		if option, declared := day.Option(name); !declared || value != option.Default {
+			changed[name] = value
+		}
+	}
+	return formatOptions(changed)
+}
+
+func (k *knownAnswers) find(year, day, part int, options, inputHash string) (knownAnswer, bool) {
+	for _, known := range k.Answers {
+		if known.Year == year && known.Day == day && known.Part == part && known.Options == options && known.InputHash == inputHash {
+			return known, true
+		}
+	}
+	return knownAnswer{}, false
+}
+
+// Records the answer of a solved part, replacing the one of the same input
+func (k *knownAnswers) accept(solved solvedJob) {
+	accepted := knownAnswer{
+		Year:      solved.job.day.Year,
+		Day:       solved.Day,
+		Part:      solved.Part,
+		Source:    solved.job.source,
+		Options:   knownOptions(solved.job.day, solved.job.opts),
+		InputHash: solved.inputHash,
+		Answer:    solved.Answer,
+	}
+	for index, known := range k.Answers {
+		if known.Year == accepted.Year && known.Day == accepted.Day && known.Part == accepted.Part && known.Options == accepted.Options && known.InputHash == accepted.InputHash {
+			k.Answers[index] = accepted
+			return
+		}
+	}
+	k.Answers = append(k.Answers, accepted)
+}
+
+// Compares a solved part to the store: "OK", "REGRESSION ..." or "" when
+// no answer was accepted for this input
+func (k *knownAnswers) check(solved solvedJob) (string, bool) {
+	if solved.inputHash == "" {
+		return "", true
+	}
+	known, found := k.find(solved.job.day.Year, solved.Day, solved.Part, knownOptions(solved.job.day, solved.job.opts), solved.inputHash)
+	switch {
+	case !found:
+		return "", true
+	case solved.Err != nil:
+		return fmt.Sprintf("REGRESSION: expected %s", known.Answer), false
+	case solved.Answer != known.Answer:
+		return fmt.Sprintf("REGRESSION: expected %s", known.Answer), false
+	}
+	return "OK", true
+}
+
+// Flags of the input a known answer was accepted on
+func sourceInputs(source string) (inputFlags, error) {
+	inputs := inputFlags{example: -1}
+
+	switch {
+	case source == "-":
+		return inputs, fmt.Errorf("answers accepted on stdin can't be verified")
+	case source == "input":
+	case strings.HasPrefix(source, "example "):
+		example, err := strconv.Atoi(strings.TrimPrefix(source, "example "))
+		if err != nil {
+			return inputs, fmt.Errorf("unknown input source '%s'", source)
+		}
+		inputs.example = example
+	default:
+		inputs.path = source
+	}
+	return inputs, nil
+}
+
+// Options of a known answer, written as knownOptions does
+func parseKnownOptions(day puzzle.Day, options string) (puzzle.Options, error) {
+	values := map[string]string{}
+
+	if options != "" {
+		for _, pair := range strings.Split(options, ",") {
+			name, value, _ := strings.Cut(pair, "=")
+			values[name] = value
+		}
+	}
+	return day.ParseOptions(values)
+}
+
+// Job solving a known answer's part again, on the same input and options
+func knownJob(answer knownAnswer) (job, error) {
+	day, err := puzzle.Lookup(answer.Year, answer.Day)
+	if err != nil {
+		return job{}, err
+	}
+	opts, err := parseKnownOptions(day, answer.Options)
+	if err != nil {
+		return job{}, err
+	}
+	inputs, err := sourceInputs(answer.Source)
+	if err != nil {
+		return job{}, err
+	}
+	jobs, err := inputs.jobs(day, []int{answer.Part}, opts)
+	if err != nil {
+		return job{}, err
+	}
+	return jobs[0], nil
+}
+
+func verifyCommand(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	year := registerYear(fs, true)
+	dayNumber := fs.Int("day", 0, "only verify the answers of this day")
+	workers := fs.Int("workers", runtime.NumCPU(), "amount of goroutines shared by the solvers")
+	logs := logFlags{}
+	logs.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	logs.setup()
+	workpool.SetSize(*workers)
+
+	known, err := loadKnownAnswers()
+	if err != nil {
+		return err
+	}
+	type check struct {
+		known knownAnswer
+		job   job
+		err   error
+	}
+	checks := make([]check, 0, len(known.Answers))
+
+	for _, answer := range known.Answers {
+		if (*year != 0 && answer.Year != *year) || (*dayNumber != 0 && answer.Day != *dayNumber) {
+			continue
+		}
+		c := check{known: answer}
+		c.job, c.err = knownJob(answer)
+		checks = append(checks, c)
+	}
+
+	// Every case is solved again, the cache is never used
+	solved := workpool.Map(checks, func(c check) solvedJob {
+		if c.err != nil {
+			return solvedJob{}
+		}
+		return c.job.solveCached(nil)
+	})
+
+	failed := 0
+	for index, c := range checks {
+		label := fmt.Sprintf("%d day %d part %d (%s)", c.known.Year, c.known.Day, c.known.Part, c.known.Source)
+
+		switch {
+		case c.err != nil:
+			failed++
+			fmt.Printf("%s: REGRESSION: %v\n", label, c.err)
+		case solved[index].inputHash != c.known.InputHash:
+			failed++
+			fmt.Printf("%s: REGRESSION: the input changed since the answer was accepted\n", label)
+		default:
+			marker, ok := known.check(solved[index])
+			if !ok {
+				failed++
+			}
+			if solved[index].Err != nil {
+				marker += fmt.Sprintf(" (%v)", solved[index].Err)
+			}
+			fmt.Printf("%s: %s %s\n", label, solved[index].Answer, marker)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d known answers regressed", failed, len(checks))
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+
+	"bta/aoc23/puzzle"
+)
+
+// Options left to their default don't take part in the key, so a day gaining
+// an option keeps its answers
+func TestKnownOptions(t *testing.T) {
+	day, err := puzzle.Lookup(2023, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	known := &knownAnswers{Answers: []knownAnswer{{Year: 2023, Day: 11, Part: 1, InputHash: "hash", Answer: "374"}}}
+	solved := solvedJob{
+		Result:    puzzle.Result{Year: 2023, Day: 11, Part: 1, Answer: "374"},
+		job:       job{day: day, part: 1, opts: day.DefaultOptions()},
+		inputHash: "hash",
+	}
+	if marker, ok := known.check(solved); marker != "OK" || !ok {
+		t.Errorf("default options: %q", marker)
+	}
+
+	solved.job.opts = puzzle.Options{"expansion": 10, "older-expansion": 1000000, "bigint": 0}
+	if key := knownOptions(day, solved.job.opts); key != "expansion=10" {
+		t.Errorf("options key %q, expected expansion=10", key)
+	}
+	if marker, ok := known.check(solved); marker != "" || !ok {
+		t.Errorf("other options: %q", marker)
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"bta/aoc23/puzzle"
+)
+
+// Puzzles unlock at midnight EST
+var eventZone = time.FixedZone("EST", -5*60*60)
+
+// Private leaderboard as exported by adventofcode.com (the [API] link of the
+// leaderboard page)
+type leaderboardExport struct {
+	Event   string                       `json:"event"`
+	Members map[string]leaderboardMember `json:"members"`
+}
+
+type leaderboardMember struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Stars      int    `json:"stars"`
+	LocalScore int    `json:"local_score"`
+	// Keyed by day, then by part
+	CompletionDayLevel map[string]map[string]struct {
+		GetStarTs int64 `json:"get_star_ts"`
+	} `json:"completion_day_level"`
+}
+
+// Member of a leaderboard, with the time each star was earned at (zero when
+// it wasn't)
+type memberStars struct {
+	name   string
+	stars  int
+	score  int
+	earned [eventDays][2]time.Time
+}
+
+// Medians of a day over the members who earned its stars
+type daySummary struct {
+	day     int
+	members int
+	// Time from the unlock to each star, and from the first star to the second
+	part1, part2, delta time.Duration
+	// Parts this repo has a solver for
+	solvers [2]bool
+}
+
+func parseLeaderboard(data []byte) (int, []memberStars, error) {
+	export := leaderboardExport{}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return 0, nil, err
+	}
+	year, err := strconv.Atoi(export.Event)
+	if err != nil {
+		return 0, nil, fmt.Errorf("the event should be a year, got '%s'", export.Event)
+	}
+
+	members := make([]memberStars, 0, len(export.Members))
+	for _, member := range export.Members {
+		stars := memberStars{name: member.Name, stars: member.Stars, score: member.LocalScore}
+		if stars.name == "" {
+			stars.name = fmt.Sprintf("(anonymous user #%d)", member.ID)
+		}
+		for dayKey, parts := range member.CompletionDayLevel {
+			day, err := strconv.Atoi(dayKey)
+			if err != nil || day < 1 || day > eventDays {
+				return 0, nil, fmt.Errorf("%s: '%s' isn't a day", stars.name, dayKey)
+			}
+			for partKey, star := range parts {
+				part, err := strconv.Atoi(partKey)
+				if err != nil || part < 1 || part > 2 {
+					return 0, nil, fmt.Errorf("%s: day %d has no part '%s'", stars.name, day, partKey)
+				}
+				stars.earned[day-1][part-1] = time.Unix(star.GetStarTs, 0)
+			}
+		}
+		members = append(members, stars)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].score != members[j].score {
+			return members[i].score > members[j].score
+		}
+		return members[i].name < members[j].name
+	})
+	return year, members, nil
+}
+
+func unlockTime(year, day int) time.Time {
+	return time.Date(year, time.December, day, 0, 0, 0, 0, eventZone)
+}
+
+func medianDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	return sorted[len(sorted)/2]
+}
+
+// Summaries of the days at least one member has a star of
+func summarizeDays(year int, members []memberStars) []daySummary {
+	summaries := make([]daySummary, 0)
+
+	for day := 1; day <= eventDays; day++ {
+		summary := daySummary{day: day}
+		part1, part2, delta := []time.Duration{}, []time.Duration{}, []time.Duration{}
+		for _, member := range members {
+			first, second := member.earned[day-1][0], member.earned[day-1][1]
+			if first.IsZero() {
+				continue
+			}
+			summary.members++
+			part1 = append(part1, first.Sub(unlockTime(year, day)))
+			if !second.IsZero() {
+				part2 = append(part2, second.Sub(unlockTime(year, day)))
+				delta = append(delta, second.Sub(first))
+			}
+		}
+		if summary.members == 0 {
+			continue
+		}
+		summary.part1, summary.part2, summary.delta = medianDuration(part1), medianDuration(part2), medianDuration(delta)
+		if solved, err := puzzle.Lookup(year, day); err == nil {
+			for part := 1; part <= 2; part++ {
+				_, summary.solvers[part-1] = solved.Part(part)
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// "-" for the stars not earned
+func formatStarTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.In(eventZone).Format("Jan 02 15:04:05")
+}
+
+func formatSolveTime(d time.Duration) string {
+	if d == 0 {
+		return "-"
+	}
+	return d.Round(time.Second).String()
+}
+
+func leaderboardCommand(args []string) error {
+	fs := flag.NewFlagSet("leaderboard", flag.ContinueOnError)
+	path := fs.String("file", "", "leaderboard exported as JSON from adventofcode.com")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *path == "" {
+		return fmt.Errorf("-file is required")
+	}
+	data, err := os.ReadFile(*path)
+	if err != nil {
+		return err
+	}
+	year, members, err := parseLeaderboard(data)
+	if err != nil {
+		return fmt.Errorf("leaderboard '%s': %v", *path, err)
+	}
+
+	fmt.Printf("Advent of Code %d, %d members (star times in EST, delta from part 1 to part 2)\n", year, len(members))
+	for _, member := range members {
+		fmt.Printf("\n%s: %d stars, local score %d\n", member.name, member.stars, member.score)
+		for day := 1; day <= eventDays; day++ {
+			first, second := member.earned[day-1][0], member.earned[day-1][1]
+			if first.IsZero() {
+				continue
+			}
+			delta := time.Duration(0)
+			if !second.IsZero() {
+				delta = second.Sub(first)
+			}
+			fmt.Printf("  day %2d  %-15s  %-15s  %s\n", day, formatStarTime(first), formatStarTime(second), formatSolveTime(delta))
+		}
+	}
+
+	fmt.Printf("\nmedian times from the unlock:\n")
+	for _, summary := range summarizeDays(year, members) {
+		solvers := "no solver"
+		switch {
+		case summary.solvers[0] && summary.solvers[1]:
+			solvers = "solved here"
+		case summary.solvers[0]:
+			solvers = "part 1 solved here"
+		}
+		fmt.Printf("  day %2d  %2d members  part 1 %-10s part 2 %-10s delta %-10s %s\n", summary.day, summary.members,
+			formatSolveTime(summary.part1), formatSolveTime(summary.part2), formatSolveTime(summary.delta), solvers)
+	}
+	return nil
+}
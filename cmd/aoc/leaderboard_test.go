@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLeaderboard(t *testing.T) {
+	data, err := os.ReadFile("testdata/leaderboard.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	year, members, err := parseLeaderboard(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if year != 2023 {
+		t.Errorf("year %d, expected 2023", year)
+	}
+
+	names := []string{}
+	for _, member := range members {
+		names = append(names, member.name)
+	}
+	if len(names) != 3 || names[0] != "alice" || names[1] != "bob" || names[2] != "(anonymous user #42)" {
+		t.Errorf("members %v, expected them by score", names)
+	}
+	if earned := members[0].earned[0][1]; earned.Sub(unlockTime(2023, 1)) != 25*time.Minute {
+		t.Errorf("alice got day 1 part 2 at %v, expected 25 minutes after the unlock", earned)
+	}
+
+	expected := []daySummary{
+		{day: 1, members: 3, part1: 20 * time.Minute, part2: time.Hour, delta: 40 * time.Minute, solvers: [2]bool{true, true}},
+		{day: 2, members: 2, part1: 3 * time.Hour, part2: 90 * time.Minute, delta: 30 * time.Minute, solvers: [2]bool{true, true}},
+		{day: 19, members: 1, part1: 2 * time.Hour},
+	}
+	summaries := summarizeDays(year, members)
+	if len(summaries) != len(expected) {
+		t.Fatalf("%d days summarized, expected %d", len(summaries), len(expected))
+	}
+	for index, summary := range summaries {
+		if summary != expected[index] {
+			t.Errorf("day %d: %+v, expected %+v", summary.day, summary, expected[index])
+		}
+	}
+}
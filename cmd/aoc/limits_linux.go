@@ -0,0 +1,24 @@
+package main
+
+import (
+	"syscall"
+	"time"
+)
+
+// Limits the CPU time and the address space of the process, the kernel kills
+// it once the CPU time is spent and allocations fail past the address space
+func setLimits(cpu time.Duration, memory int) error {
+	if cpu > 0 {
+		seconds := uint64((cpu + time.Second - 1) / time.Second)
+		if err := syscall.Setrlimit(syscall.RLIMIT_CPU, &syscall.Rlimit{Cur: seconds, Max: seconds}); err != nil {
+			return err
+		}
+	}
+	if memory > 0 {
+		size := uint64(memory) << 20
+		if err := syscall.Setrlimit(syscall.RLIMIT_AS, &syscall.Rlimit{Cur: size, Max: size}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+func setLimits(cpu time.Duration, memory int) error {
+	if cpu > 0 || memory > 0 {
+		return fmt.Errorf("resource limits are only supported on Linux, use -cpu 0 -memory 0")
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"os"
+)
+
+// Flags choosing the diagnostics written to stderr, stdout only gets the
+// answers
+type logFlags struct {
+	verbose bool
+	quiet   bool
+	json    bool
+}
+
+func (f *logFlags) register(fs *flag.FlagSet) {
+	fs.BoolVar(&f.verbose, "v", false, "log debug details of the solvers on stderr")
+	fs.BoolVar(&f.quiet, "q", false, "only log errors")
+	fs.BoolVar(&f.json, "log-json", false, "log as JSON lines")
+}
+
+// Installs the default slog logger every day logs through
+func (f *logFlags) setup() {
+	level := slog.LevelInfo
+	switch {
+	case f.verbose:
+		level = slog.LevelDebug
+	case f.quiet:
+		level = slog.LevelError
+	}
+	options := &slog.HandlerOptions{Level: level}
+
+	if f.json {
+		slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, options)))
+	} else {
+		slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, options)))
+	}
+}
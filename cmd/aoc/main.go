@@ -0,0 +1,70 @@
+// Command aoc runs the puzzle solvers registered by every day directory.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+	// Started by aoc itself, not listed
+	hidden bool
+}
+
+var commands = []command{
+	{name: "run", usage: "solve one day (or every day) and print the answers", run: runCommand},
+	{name: "serve", usage: "start the local dashboard", run: serveCommand},
+	{name: "api", usage: "serve the solvers as a JSON API", run: apiCommand},
+	{name: "repl", usage: "explore the parsed input of a day", run: replCommand},
+	{name: "watch", usage: "solve a day again each time its input changes", run: watchCommand},
+	{name: "cache", usage: "prune the cached answers", run: cacheCommand},
+	{name: "verify", usage: "solve every known answer again and flag the regressions", run: verifyCommand},
+	{name: "stars", usage: "print the calendar of the solved parts", run: starsCommand},
+	{name: "inputs", usage: "seal or unseal the puzzle inputs", run: inputsCommand},
+	{name: "compare", usage: "run a solver plugin against the built-in solver of its day", run: compareCommand},
+	{name: "read", usage: "read the statement of a day", run: readCommand},
+	{name: "search", usage: "search the statements of every day", run: searchCommand},
+	{name: "inspect", usage: "print structural facts about the input of a day", run: inspectCommand},
+	{name: "leaderboard", usage: "analyze a private leaderboard exported as JSON", run: leaderboardCommand},
+	{name: "child", run: childCommand, hidden: true},
+	{name: "determinism", usage: "solve a day in fresh processes and flag the answers that vary", run: determinismCommand},
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: aoc <command> [flags]\n\ncommands:\n")
+	for _, cmd := range commands {
+		if cmd.hidden {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", cmd.name, cmd.usage)
+	}
+	fmt.Fprintf(os.Stderr, "\nrun 'aoc <command> -h' to list the flags of a command\n")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	for _, cmd := range commands {
+		if cmd.name != os.Args[1] {
+			continue
+		}
+		if err := cmd.run(os.Args[2:]); err != nil {
+			if err == flag.ErrHelp {
+				os.Exit(2)
+			}
+			// Not logged: slog takes over the log package once a command
+			// set its handler up
+			fmt.Fprintf(os.Stderr, "%s: %v\n", cmd.name, err)
+			os.Exit(1)
+		}
+		return
+	}
+	usage()
+	os.Exit(2)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"bta/aoc23/puzzle"
+)
+
+// Options of a run read from a JSON file (-options), keyed by the number of
+// the days of the -year event:
+//
+//	{"11": {"expansion": 10}, "17": {"ultra-min-move": 3, "ultra-max-move": 6}}
+//
+// Values are kept as written, the days parse them as they parse -opt.
+type optionsFile map[int]map[string]string
+
+// Reads an options file and checks every day of it against the options the
+// day declares, not only the days being run
+func loadOptionsFile(path string, year int) (optionsFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	raw := map[string]map[string]json.Number{}
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("options file '%s': %v", path, err)
+	}
+
+	file := optionsFile{}
+	for key, options := range raw {
+		dayNumber, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("options file '%s': days are keyed by number, got '%s'", path, key)
+		}
+		day, err := puzzle.Lookup(year, dayNumber)
+		if err != nil {
+			return nil, fmt.Errorf("options file '%s': %v", path, err)
+		}
+		values := make(map[string]string, len(options))
+		for name, value := range options {
+			values[name] = value.String()
+		}
+		if _, err := day.ParseOptions(values); err != nil {
+			return nil, fmt.Errorf("options file '%s', day %d: %v", path, dayNumber, err)
+		}
+		file[dayNumber] = values
+	}
+	return file, nil
+}
+
+// Effective value of an option, and what set it
+type optionValue struct {
+	name   string
+	value  int
+	source string
+}
+
+// Options of the day: the defaults, overridden by the options file, then by
+// -opt and -bigint
+func (f *inputFlags) resolveOptions(day puzzle.Day) (puzzle.Options, []optionValue, error) {
+	if f.optionsPath != "" && f.file == nil {
+		file, err := loadOptionsFile(f.optionsPath, f.year)
+		if err != nil {
+			return nil, nil, err
+		}
+		f.file = file
+	}
+	values, sources := map[string]string{}, map[string]string{}
+
+	if day.Year == f.year {
+		for name, value := range f.file[day.Number] {
+			values[name], sources[name] = value, f.optionsPath
+		}
+	}
+	for name, value := range f.values {
+		values[name], sources[name] = value, "-opt"
+	}
+	opts, err := day.ParseOptions(values)
+	if err != nil {
+		return nil, nil, err
+	}
+	// -bigint only sets the option of the days declaring it
+	if _, declared := opts[puzzle.BigInt.Name]; declared && f.bigint {
+		opts[puzzle.BigInt.Name], sources[puzzle.BigInt.Name] = 1, "-bigint"
+	}
+
+	effective := make([]optionValue, 0, len(opts))
+	for name, value := range opts {
+		source, set := sources[name]
+		if !set {
+			source = "default"
+		}
+		effective = append(effective, optionValue{name: name, value: value, source: source})
+	}
+	sort.Slice(effective, func(i, j int) bool { return effective[i].name < effective[j].name })
+	return opts, effective, nil
+}
+
+// Prints the effective options of a day, for -explain
+func explainOptions(day puzzle.Day, effective []optionValue) {
+	if len(effective) == 0 {
+		fmt.Printf("day %d has no options\n", day.Number)
+		return
+	}
+	fmt.Printf("day %d options:\n", day.Number)
+	for _, option := range effective {
+		fmt.Printf("  %-24s %s\n", fmt.Sprintf("%s=%d", option.name, option.value), option.source)
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"bta/aoc23/puzzle"
+)
+
+const (
+	readWidth = 100
+
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiDim     = "\x1b[2m"
+	ansiExample = "\x1b[36m"
+	ansiMatch   = "\x1b[1;33m"
+)
+
+// Colors are only written to terminals, the pager gets them too
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Wraps prose on words, to width columns
+func wrap(text string, width int) []string {
+	lines := make([]string, 0)
+	line := ""
+
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Writes a paragraph, examples are indented and labelled with their block
+// index. match highlights the words a search found, it can be nil.
+func writeParagraph(out io.Writer, paragraph puzzle.Paragraph, color bool, match *regexp.Regexp) {
+	style := func(code, text string) string {
+		if !color {
+			return text
+		}
+		return code + text + ansiReset
+	}
+	highlight := func(text string) string {
+		if match == nil || !color {
+			return text
+		}
+		return match.ReplaceAllStringFunc(text, func(found string) string { return ansiMatch + found + ansiReset })
+	}
+
+	switch paragraph.Kind {
+	case puzzle.HeadingParagraph:
+		fmt.Fprintln(out, style(ansiBold, paragraph.Text))
+	case puzzle.ExampleParagraph:
+		fmt.Fprintln(out, style(ansiDim, fmt.Sprintf("    [example %d]", paragraph.Block)))
+		for _, line := range strings.Split(paragraph.Text, "\n") {
+			fmt.Fprintln(out, "    "+style(ansiExample, highlight(line)))
+		}
+	default:
+		for _, line := range wrap(paragraph.Text, readWidth) {
+			fmt.Fprintln(out, highlight(line))
+		}
+	}
+}
+
+// Pages text through $PAGER (less by default) when stdout is a terminal
+func page(text []byte) error {
+	if !isTerminal(os.Stdout) {
+		_, err := os.Stdout.Write(text)
+		return err
+	}
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = []string{"less", "-R", "-F"}
+	}
+	cmd := exec.Command(pager[0], pager[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = bytes.NewReader(text), os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		if _, isExit := err.(*exec.ExitError); isExit {
+			return err
+		}
+		// No pager, the text is printed as is
+		_, err := os.Stdout.Write(text)
+		return err
+	}
+	return nil
+}
+
+func readCommand(args []string) error {
+	fs := flag.NewFlagSet("read", flag.ContinueOnError)
+	year := registerYear(fs, false)
+	dayNumber := fs.Int("day", 0, "day whose statement is read")
+	part := fs.Int("part", 0, "only read this part (1 or 2), both when 0")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	day, err := puzzle.Lookup(*year, *dayNumber)
+	if err != nil {
+		return err
+	}
+	paragraphs, err := day.Statement()
+	if err != nil {
+		return err
+	}
+	color := isTerminal(os.Stdout)
+	out := bytes.Buffer{}
+
+	for _, paragraph := range paragraphs {
+		if *part != 0 && paragraph.Part != *part {
+			continue
+		}
+		writeParagraph(&out, paragraph, color, nil)
+		fmt.Fprintln(&out)
+	}
+	return page(out.Bytes())
+}
+
+// Paragraph found by aoc search
+type searchHit struct {
+	day       puzzle.Day
+	paragraph puzzle.Paragraph
+}
+
+func searchCommand(args []string) error {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	year := registerYear(fs, true)
+	withExamples := fs.Bool("examples", false, "also search the example blocks")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("usage: aoc search [-year Y] [-examples] <words>")
+	}
+
+	// Every word must be in the paragraph, whatever the case
+	words := make([]*regexp.Regexp, 0, fs.NArg())
+	quoted := make([]string, 0, fs.NArg())
+	for _, word := range strings.Fields(strings.Join(fs.Args(), " ")) {
+		words = append(words, regexp.MustCompile("(?i)"+regexp.QuoteMeta(word)))
+		quoted = append(quoted, regexp.QuoteMeta(word))
+	}
+	match := regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+
+	hits := make([]searchHit, 0)
+	searched := puzzle.Days()
+	if *year != 0 {
+		searched = puzzle.YearDays(*year)
+	}
+	for _, day := range searched {
+		paragraphs, err := day.Statement()
+		if err != nil {
+			return err
+		}
+		for _, paragraph := range paragraphs {
+			if paragraph.Kind == puzzle.ExampleParagraph && !*withExamples {
+				continue
+			}
+			found := true
+			for _, word := range words {
+				found = found && word.MatchString(paragraph.Text)
+			}
+			if found {
+				hits = append(hits, searchHit{day: day, paragraph: paragraph})
+			}
+		}
+	}
+
+	color := isTerminal(os.Stdout)
+	days := map[[2]int]bool{}
+	for _, hit := range hits {
+		days[[2]int{hit.day.Year, hit.day.Number}] = true
+		label := fmt.Sprintf("%d day %d part %d, %s (line %d)", hit.day.Year, hit.day.Number, hit.paragraph.Part, hit.day.Title, hit.paragraph.Line)
+		if color {
+			label = ansiBold + label + ansiReset
+		}
+		fmt.Println(label)
+		writeParagraph(os.Stdout, hit.paragraph, color, match)
+		fmt.Println()
+	}
+	fmt.Printf("%d paragraphs in %d days\n", len(hits), len(days))
+	return nil
+}
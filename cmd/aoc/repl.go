@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"bta/aoc23/puzzle"
+)
+
+func printReplHelp(out io.Writer, commands []puzzle.Command) {
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-28s %s\n", strings.TrimSpace(cmd.Name+" "+cmd.Args), cmd.Usage)
+	}
+	fmt.Fprintf(out, "  %-28s %s\n", "help", "list the commands")
+	fmt.Fprintf(out, "  %-28s %s\n", "quit", "leave the repl")
+}
+
+// Reads commands from in until it is closed or quit is entered, command
+// errors are printed without leaving the repl
+func repl(prompt string, commands []puzzle.Command, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+
+	for fmt.Fprint(out, prompt); scanner.Scan(); fmt.Fprint(out, prompt) {
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "quit", "exit":
+			return nil
+		case "help":
+			printReplHelp(out, commands)
+			continue
+		}
+
+		found := false
+		for _, cmd := range commands {
+			if cmd.Name != fields[0] {
+				continue
+			}
+			found = true
+			if err := cmd.Run(fields[1:], out); err != nil {
+				fmt.Fprintf(out, "error: %v\n", err)
+			}
+		}
+		if !found {
+			fmt.Fprintf(out, "unknown command '%s', try help\n", fields[0])
+		}
+	}
+	fmt.Fprintln(out)
+	return scanner.Err()
+}
+
+func replCommand(args []string) error {
+	fs := flag.NewFlagSet("repl", flag.ContinueOnError)
+	dayNumber := fs.Int("day", 0, "day to explore")
+	inputs := inputFlags{}
+	inputs.register(fs)
+	logs := logFlags{}
+	logs.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	logs.setup()
+	if inputs.path == "-" {
+		return fmt.Errorf("the repl reads its commands from stdin, the input must be a file")
+	}
+
+	day, err := puzzle.Lookup(inputs.year, *dayNumber)
+	if err != nil {
+		return err
+	}
+	if day.Explore == nil {
+		return fmt.Errorf("day %d has no repl commands", day.Number)
+	}
+	input, opts, err := inputs.load(day)
+	if err != nil {
+		return err
+	}
+	commands, err := day.Explore(input, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("--- Day %d: %s ---\n", day.Number, day.Title)
+	printReplHelp(os.Stdout, commands)
+	return repl(fmt.Sprintf("day%02d> ", day.Number), commands, os.Stdin, os.Stdout)
+}
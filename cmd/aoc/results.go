@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"bta/aoc23/puzzle"
+)
+
+const (
+	stateDir        = ".aoc"
+	resultsFilename = "results.json"
+)
+
+// Latest answer of a part on its real input
+type record struct {
+	Answer   string        `json:"answer,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+	SolvedAt time.Time     `json:"solvedAt"`
+}
+
+type results map[string]record
+
+func resultKey(year, day, part int) string {
+	return fmt.Sprintf("%d/%d/%d", year, day, part)
+}
+
+func resultsPath() string {
+	return filepath.Join(puzzle.Root, stateDir, resultsFilename)
+}
+
+func loadResults() (results, error) {
+	file, err := os.ReadFile(resultsPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return results{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	r := results{}
+	if err := json.Unmarshal(file, &r); err != nil {
+		return nil, fmt.Errorf("couldn't parse %s: %v", resultsPath(), err)
+	}
+	return r, nil
+}
+
+func (r results) save() error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(resultsPath()), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(resultsPath(), data, 0o644)
+}
+
+func (r results) record(result puzzle.Result) {
+	rec := record{
+		Answer:   result.Answer,
+		Duration: result.Duration,
+		SolvedAt: time.Now(),
+	}
+	if result.Err != nil {
+		rec.Error = result.Err.Error()
+	}
+	r[resultKey(result.Year, result.Day, result.Part)] = rec
+}
+
+func (r results) latest(year, day, part int) (record, bool) {
+	rec, ok := r[resultKey(year, day, part)]
+	return rec, ok
+}
@@ -0,0 +1,425 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"bta/aoc23/grid"
+	"bta/aoc23/puzzle"
+	"bta/aoc23/workpool"
+)
+
+// Collects repeated -opt name=value flags
+type optionFlags map[string]string
+
+func (o optionFlags) String() string {
+	parts := make([]string, 0, len(o))
+
+	for name, value := range o {
+		parts = append(parts, name+"="+value)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (o optionFlags) Set(value string) error {
+	name, optionValue, found := strings.Cut(value, "=")
+
+	if !found {
+		return fmt.Errorf("option must be written as name=value")
+	}
+	o[name] = optionValue
+	return nil
+}
+
+// Flags selecting the input and the options a day is solved with
+type inputFlags struct {
+	year        int
+	path        string
+	example     int
+	values      optionFlags
+	bigint      bool
+	optionsPath string
+	palette     grid.Palette
+	// Loaded on the first call to options
+	file optionsFile
+}
+
+func (f *inputFlags) register(fs *flag.FlagSet) {
+	fs.IntVar(&f.year, "year", puzzle.LatestYear(), "event year of the days")
+	fs.StringVar(&f.path, "input", "", "input file instead of the day's input, '-' reads stdin")
+	fs.IntVar(&f.example, "example", -1, "solve the Nth example block of instructions.txt instead of the input")
+	f.values = optionFlags{}
+	fs.Var(f.values, "opt", "day option as name=value, can be repeated")
+	fs.BoolVar(&f.bigint, "bigint", false, "use math/big for every computation of the days whose answers can overflow")
+	fs.StringVar(&f.optionsPath, "options", "", "JSON file of day options, keyed by day number; -opt and -bigint override it")
+	f.palette = grid.DefaultPalette
+	fs.Var(&f.palette, "palette", "colours of the PNG grid inputs as rrggbb=c pairs, comma separated")
+}
+
+// For the commands picking days without the input flags, 0 being every year
+// when allYears
+func registerYear(fs *flag.FlagSet, allYears bool) *int {
+	if allYears {
+		return fs.Int("year", 0, "event year of the days, every year when 0")
+	}
+	return fs.Int("year", puzzle.LatestYear(), "event year of the days")
+}
+
+// Flags selecting the same input and options in another aoc process, stdin
+// can't be given again
+func (f *inputFlags) args() ([]string, error) {
+	if f.path == "-" {
+		return nil, fmt.Errorf("the input must be a file, stdin can only be read once")
+	}
+	args := []string{"-year", strconv.Itoa(f.year), "-example", strconv.Itoa(f.example)}
+	if f.path != "" {
+		args = append(args, "-input", f.path)
+	}
+	for name, value := range f.values {
+		args = append(args, "-opt", name+"="+value)
+	}
+	if f.bigint {
+		args = append(args, "-bigint")
+	}
+	if f.optionsPath != "" {
+		args = append(args, "-options", f.optionsPath)
+	}
+	return append(args, "-palette", f.palette.String()), nil
+}
+
+// The day's real input is used when neither -input nor -example are set
+func (f *inputFlags) isRealInput() bool {
+	return f.path == "" && f.example < 0
+}
+
+func (f *inputFlags) load(day puzzle.Day) ([]byte, puzzle.Options, error) {
+	input, err := readInput(day, f.path, f.example, f.palette)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts, err := f.options(day)
+	if err != nil {
+		return nil, nil, err
+	}
+	return input, opts, nil
+}
+
+func (f *inputFlags) options(day puzzle.Day) (puzzle.Options, error) {
+	opts, _, err := f.resolveOptions(day)
+	return opts, err
+}
+
+// Path of the input file streaming parts can read, "" when the input is an
+// example block, stdin, a grid to convert or the copy embedded in the binary
+func (f *inputFlags) streamPath(day puzzle.Day) string {
+	switch {
+	case f.example >= 0 || f.path == "-" || (day.Grid && grid.IsGridFile(f.path)):
+		return ""
+	case f.path == "" && !day.LocalInput():
+		return ""
+	case f.path == "":
+		return day.InputPath()
+	default:
+		return f.path
+	}
+}
+
+// Jobs solving parts of a day, the input is only loaded when one of the
+// parts can't stream it
+func (f *inputFlags) jobs(day puzzle.Day, parts []int, opts puzzle.Options) ([]job, error) {
+	jobs := make([]job, 0, len(parts))
+	var input []byte
+	loaded := false
+
+	for _, partNumber := range parts {
+		p, _ := day.Part(partNumber)
+		j := job{day: day, part: partNumber, source: f.source(), path: f.streamPath(day), opts: opts}
+
+		if j.path == "" || p.Stream == nil {
+			if !loaded {
+				var err error
+				if input, err = readInput(day, f.path, f.example, f.palette); err != nil {
+					return nil, err
+				}
+				loaded = true
+			}
+			j.path, j.input = "", input
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, nil
+}
+
+// Describes the input the flags select, as written in the known answers
+func (f *inputFlags) source() string {
+	switch {
+	case f.example >= 0:
+		return fmt.Sprintf("example %d", f.example)
+	case f.path == "":
+		return "input"
+	default:
+		return f.path
+	}
+}
+
+func readInput(day puzzle.Day, path string, example int, palette grid.Palette) ([]byte, error) {
+	if example >= 0 {
+		examples, err := day.Examples()
+		if err != nil {
+			return nil, err
+		}
+		if example >= len(examples) {
+			return nil, fmt.Errorf("day %d has only %d example blocks", day.Number, len(examples))
+		}
+		return []byte(examples[example]), nil
+	}
+	switch path {
+	case "":
+		return day.ReadInput()
+	case "-":
+		input, err := io.ReadAll(os.Stdin)
+		return puzzle.Normalize(input), err
+	default:
+		return readInputFile(day, path, palette)
+	}
+}
+
+// Reads an input file, grid days also read CSV, JSON and PNG grids
+func readInputFile(day puzzle.Day, path string, palette grid.Palette) ([]byte, error) {
+	if !day.Grid || !grid.IsGridFile(path) {
+		return puzzle.ReadInput(path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read input file '%s'\n%w", path, err)
+	}
+	return grid.Load(path, data, palette)
+}
+
+// A part to solve along with its input
+type job struct {
+	day  puzzle.Day
+	part int
+	// Input description, see inputFlags.source
+	source string
+	// Streaming parts read the input file themselves, the others get the
+	// input loaded once for the day
+	path  string
+	input []byte
+	opts  puzzle.Options
+	// Solved in a child process when set
+	isolation *isolation
+}
+
+func (j job) solve() puzzle.Result {
+	if j.isolation != nil {
+		return j.isolation.solve(context.Background(), j)
+	}
+	if j.path == "" {
+		return j.day.Run(j.part, j.input, j.opts)
+	}
+	file, err := puzzle.OpenInput(j.path)
+	if err != nil {
+		return puzzle.Result{Year: j.day.Year, Day: j.day.Number, Part: j.part, Err: fmt.Errorf("couldn't open input file '%s'\n%v", j.path, err)}
+	}
+	defer file.Close()
+	return j.day.RunStream(j.part, file, j.opts)
+}
+
+// SHA-256 of the normalized input, the file is hashed as it is read for
+// streaming parts. Both give the same hash whatever the line endings, so a
+// streamed input and its loaded copy share their cache entries and known
+// answers.
+func (j job) inputHash() (string, error) {
+	hash := sha256.New()
+
+	if j.path == "" {
+		hash.Write(puzzle.Normalize(j.input))
+	} else {
+		file, err := puzzle.OpenInput(j.path)
+		if err != nil {
+			return "", err
+		}
+		defer file.Close()
+		normalizer := puzzle.NewNormalizer(hash)
+		if _, err := io.Copy(normalizer, file); err != nil {
+			return "", err
+		}
+		normalizer.Close()
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+type solvedJob struct {
+	puzzle.Result
+	job job
+	// Empty when the input couldn't be read
+	inputHash string
+	cached    bool
+}
+
+// Returns the cached answer of the job when there is one, otherwise solves
+// it and caches the answer. A nil cache always solves.
+func (j job) solveCached(cache *answerCache) solvedJob {
+	inputHash, err := j.inputHash()
+	if err != nil {
+		// Solving reports the input error
+		return solvedJob{Result: j.solve(), job: j}
+	}
+	solved := solvedJob{job: j, inputHash: inputHash}
+	if cache == nil {
+		solved.Result = j.solve()
+		return solved
+	}
+	key := cache.key(j.day.Year, j.day.Number, j.part, j.opts, inputHash)
+
+	if result, found := cache.get(key); found {
+		solved.Result, solved.cached = result, true
+		return solved
+	}
+	solved.Result = j.solve()
+	if solved.Err == nil {
+		if err := cache.put(key, solved.Result); err != nil {
+			slog.Warn("couldn't cache the answer", "day", j.day.Number, "part", j.part, "err", err)
+		}
+	}
+	return solved
+}
+
+func runCommand(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	dayNumber := fs.Int("day", 0, "day to solve, every day when 0")
+	part := fs.Int("part", 0, "part to solve (1 or 2), both when 0")
+	workers := fs.Int("workers", runtime.NumCPU(), "amount of goroutines shared by the solvers")
+	noCache := fs.Bool("no-cache", false, "solve every part even when its answer is cached")
+	accept := fs.Bool("accept", false, "record the answers as known, later runs flag the ones that change")
+	explain := fs.Bool("explain", false, "print the options each day is solved with, and what set them")
+	timings := fs.Bool("timings", true, "print how long each part took")
+	isolate := fs.Bool("isolate", false, "solve each part in a child process limited by -cpu and -memory")
+	cpu := fs.Duration("cpu", 5*time.Minute, "CPU time an isolated part may use, no limit when 0")
+	memory := fs.Int("memory", 4096, "address space an isolated part may use in MiB, no limit when 0")
+	inputs := inputFlags{}
+	inputs.register(fs)
+	logs := logFlags{}
+	logs.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	logs.setup()
+	workpool.SetSize(*workers)
+
+	days := puzzle.YearDays(inputs.year)
+	if len(days) == 0 {
+		return fmt.Errorf("no day of %d has a solver", inputs.year)
+	}
+	if *dayNumber != 0 {
+		day, err := puzzle.Lookup(inputs.year, *dayNumber)
+		if err != nil {
+			return err
+		}
+		days = []puzzle.Day{day}
+	}
+	history, err := loadResults()
+	if err != nil {
+		return err
+	}
+	known, err := loadKnownAnswers()
+	if err != nil {
+		return err
+	}
+
+	var cache *answerCache
+	if !*noCache {
+		if cache, err = openCache(); err != nil {
+			return err
+		}
+	}
+
+	jobs := make([]job, 0)
+	for _, day := range days {
+		opts, effective, err := inputs.resolveOptions(day)
+		if err != nil {
+			return err
+		}
+		if *explain {
+			explainOptions(day, effective)
+		}
+		parts := make([]int, 0, len(day.Parts))
+		for partNumber := 1; partNumber <= len(day.Parts); partNumber++ {
+			if _, solved := day.Part(partNumber); (*part != 0 && partNumber != *part) || (*part == 0 && !solved) {
+				continue
+			}
+			parts = append(parts, partNumber)
+		}
+		dayJobs, err := inputs.jobs(day, parts, opts)
+		if err != nil {
+			return err
+		}
+		jobs = append(jobs, dayJobs...)
+	}
+
+	if *isolate {
+		for index := range jobs {
+			jobs[index].isolation = &isolation{cpu: *cpu, memory: *memory}
+		}
+	}
+
+	// Parts are solved side by side but printed in order
+	solved := workpool.Map(jobs, func(j job) solvedJob {
+		return j.solveCached(cache)
+	})
+
+	failed, regressed := false, false
+	for _, solvedJob := range solved {
+		result := solvedJob.Result
+		if *accept && result.Err == nil && solvedJob.inputHash != "" {
+			known.accept(solvedJob)
+		}
+		marker, ok := known.check(solvedJob)
+		regressed = regressed || !ok
+		if marker != "" {
+			marker = " " + marker
+		}
+
+		switch {
+		case result.Err != nil:
+			failed = true
+			fmt.Printf("day %d part %d: error: %v%s\n", result.Day, result.Part, result.Err, marker)
+		case !*timings:
+			fmt.Printf("day %d part %d: %s%s\n", result.Day, result.Part, result.Answer, marker)
+		case solvedJob.cached:
+			fmt.Printf("day %d part %d: %s (cached, solved in %v)%s\n", result.Day, result.Part, result.Answer, result.Duration, marker)
+		default:
+			fmt.Printf("day %d part %d: %s (%v)%s\n", result.Day, result.Part, result.Answer, result.Duration, marker)
+		}
+		// Only real inputs feed the dashboard
+		if inputs.isRealInput() {
+			history.record(result)
+		}
+	}
+	if *accept {
+		if err := known.save(); err != nil {
+			return err
+		}
+	}
+	if err := history.save(); err != nil {
+		return err
+	}
+	if failed {
+		return fmt.Errorf("some parts couldn't be solved")
+	}
+	if regressed {
+		return fmt.Errorf("some answers differ from the known ones")
+	}
+	return nil
+}
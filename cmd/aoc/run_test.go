@@ -0,0 +1,32 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bta/aoc23/puzzle"
+)
+
+// A streamed input and its loaded copy are the same input
+func TestInputHash(t *testing.T) {
+	day, err := puzzle.Lookup(2023, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := []byte("0 3 6 9 12 15\r\n1 3 6 10 15 21\r\n\r\n")
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	streamed, err := job{day: day, part: 1, path: path}.inputHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, input := range [][]byte{raw, puzzle.Normalize(raw)} {
+		if loaded, err := (job{day: day, part: 1, input: input}).inputHash(); err != nil || loaded != streamed {
+			t.Errorf("%q hashes to %s (%v), the streamed file to %s", input, loaded, err, streamed)
+		}
+	}
+}
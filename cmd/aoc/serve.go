@@ -0,0 +1,362 @@
+package main
+
+import (
+	"context"
+	"embed"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"bta/aoc23/grid"
+	"bta/aoc23/puzzle"
+	"bta/aoc23/workpool"
+)
+
+const (
+	maxUploadSize = 32 << 20
+)
+
+//go:embed templates/*.html
+var templateFiles embed.FS
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"runs":     splitRuns,
+	"duration": formatDuration,
+	// Replaced by the year of the server, see newServer
+	"year": puzzle.LatestYear,
+}).ParseFS(templateFiles, "templates/*.html"))
+
+type exampleStatus struct {
+	Block    int
+	Expected string
+	Result   puzzle.Result
+}
+
+func (s exampleStatus) Passed() bool {
+	return s.Result.Err == nil && s.Result.Answer == s.Expected
+}
+
+type partView struct {
+	Number    int
+	Solved    bool
+	Renders   bool
+	Latest    record
+	HasLatest bool
+	Examples  []exampleStatus
+}
+
+func (p partView) ExamplesPassed() bool {
+	for _, example := range p.Examples {
+		if !example.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+type dayView struct {
+	puzzle.Day
+	Parts []partView
+	Error string
+}
+
+// Example answers of a day, by part
+type dayExamples struct {
+	parts [][]exampleStatus
+	err   error
+}
+
+func solveExamples(day puzzle.Day) dayExamples {
+	solved := dayExamples{parts: make([][]exampleStatus, len(day.Parts))}
+	examples, err := day.Examples()
+	if err != nil {
+		solved.err = err
+		return solved
+	}
+	for partNumber := 1; partNumber <= len(day.Parts); partNumber++ {
+		part, _ := day.Part(partNumber)
+
+		for _, example := range part.Examples {
+			if example.Block >= len(examples) {
+				continue
+			}
+			solved.parts[partNumber-1] = append(solved.parts[partNumber-1], exampleStatus{
+				Block:    example.Block,
+				Expected: example.Answer,
+				Result:   day.Run(partNumber, []byte(examples[example.Block]), day.DefaultOptions()),
+			})
+		}
+	}
+	return solved
+}
+
+type server struct {
+	// Year whose days the dashboard shows
+	year      int
+	templates *template.Template
+	// Solvers can run side by side, but results.json is read and rewritten
+	// by each run
+	resultsMu sync.Mutex
+	// Solved once when the server starts, by day number: the code can't
+	// change while it runs
+	examples map[int]dayExamples
+	// Uploaded inputs are solved in child processes, killed after timeout
+	children *childPool
+	timeout  time.Duration
+}
+
+func newServer(year int, children *childPool, timeout time.Duration) *server {
+	days := puzzle.YearDays(year)
+	examples := map[int]dayExamples{}
+	for index, solved := range workpool.Map(days, solveExamples) {
+		examples[days[index].Number] = solved
+	}
+
+	return &server{
+		year:      year,
+		templates: template.Must(templates.Clone()).Funcs(template.FuncMap{"year": func() int { return year }}),
+		examples:  examples,
+		children:  children,
+		timeout:   timeout,
+	}
+}
+
+func (s *server) viewDay(day puzzle.Day, history results, withExamples bool) dayView {
+	view := dayView{Day: day}
+	examples := s.examples[day.Number]
+
+	if examples.err != nil && withExamples {
+		view.Error = examples.err.Error()
+	}
+	for partNumber := 1; partNumber <= len(day.Parts); partNumber++ {
+		part, solved := day.Part(partNumber)
+		pv := partView{Number: partNumber, Solved: solved, Renders: part.Render != nil}
+		pv.Latest, pv.HasLatest = history.latest(day.Year, day.Number, partNumber)
+
+		if withExamples && partNumber <= len(examples.parts) {
+			pv.Examples = examples.parts[partNumber-1]
+		}
+		view.Parts = append(view.Parts, pv)
+	}
+	return view
+}
+
+func (s *server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	history, err := loadResults()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	views := make([]dayView, 0)
+	for _, day := range puzzle.YearDays(s.year) {
+		views = append(views, s.viewDay(day, history, true))
+	}
+	s.execute(w, "index.html", views)
+}
+
+// Routes /day/{day}[/{action}], the actions being run, render and upload
+func (s *server) handleDay(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/day/"), "/"), "/")
+	dayNumber, err := strconv.Atoi(segments[0])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	day, err := puzzle.Lookup(s.year, dayNumber)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	part, partErr := strconv.Atoi(r.FormValue("part"))
+
+	action := ""
+	if len(segments) > 1 {
+		action = segments[1]
+	}
+	// Every action works on a part
+	if action != "" && (partErr != nil || part < 1 || part > len(day.Parts)) {
+		http.Error(w, fmt.Sprintf("part must be 1 or 2, got '%s'", r.FormValue("part")), http.StatusBadRequest)
+		return
+	}
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		s.handleDayPage(w, day)
+	case action == "run" && r.Method == http.MethodPost:
+		s.handleRun(w, r, day, part)
+	case action == "render" && r.Method == http.MethodGet:
+		s.handleRender(w, r, day, part)
+	case action == "upload" && r.Method == http.MethodPost:
+		s.handleUpload(w, r, day, part)
+	default:
+		http.Error(w, "unknown action", http.StatusNotFound)
+	}
+}
+
+func (s *server) handleDayPage(w http.ResponseWriter, day puzzle.Day) {
+	history, err := loadResults()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.execute(w, "day.html", s.viewDay(day, history, true))
+}
+
+// Solves a part on the real input and records it as the latest answer
+func (s *server) handleRun(w http.ResponseWriter, r *http.Request, day puzzle.Day, part int) {
+	input, err := day.ReadInput()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	result := day.Run(part, input, day.DefaultOptions())
+
+	s.resultsMu.Lock()
+	defer s.resultsMu.Unlock()
+	history, err := loadResults()
+	if err == nil {
+		history.record(result)
+		err = history.save()
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/day/%d", day.Number), http.StatusSeeOther)
+}
+
+// Renders a part from the real input, or from an example block with ?example=N
+func (s *server) handleRender(w http.ResponseWriter, r *http.Request, day puzzle.Day, part int) {
+	var input []byte
+	var err error
+	source := "real input"
+
+	if block := r.FormValue("example"); block != "" {
+		var examples []string
+		index, _ := strconv.Atoi(block)
+
+		if examples, err = day.Examples(); err == nil && (index < 0 || index >= len(examples)) {
+			err = fmt.Errorf("day %d has no example block %d", day.Number, index)
+		} else if err == nil {
+			input = []byte(examples[index])
+			source = fmt.Sprintf("example block %d", index)
+		}
+	} else {
+		input, err = day.ReadInput()
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rows, err := day.Render(part, input, day.DefaultOptions())
+	view := struct {
+		puzzle.Day
+		Part   int
+		Source string
+		Rows   []string
+		Error  string
+	}{Day: day, Part: part, Source: source, Rows: rows}
+	if err != nil {
+		view.Error = err.Error()
+	}
+	s.execute(w, "render.html", view)
+}
+
+// Solves a part on an uploaded input in a child process, the answer isn't
+// recorded
+func (s *server) handleUpload(w http.ResponseWriter, r *http.Request, day puzzle.Day, part int) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	file, header, err := r.FormFile("input")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("couldn't read uploaded input: %v", err), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	input, err := io.ReadAll(file)
+	if err == nil && day.Grid && grid.IsGridFile(header.Filename) {
+		input, err = grid.Load(header.Filename, input, grid.DefaultPalette)
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("couldn't read uploaded input: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+	result, err := s.children.solve(ctx, job{day: day, part: part, input: input, opts: day.DefaultOptions()})
+	if err != nil {
+		result = puzzle.Result{Year: day.Year, Day: day.Number, Part: part, Err: fmt.Errorf("no answer within %v, the solver was stopped", s.timeout)}
+	}
+	s.execute(w, "upload.html", struct {
+		puzzle.Day
+		Result puzzle.Result
+	}{day, result})
+}
+
+func (s *server) execute(w http.ResponseWriter, name string, data any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.templates.ExecuteTemplate(w, name, data); err != nil {
+		slog.Error("template failed", "template", name, "err", err)
+	}
+}
+
+type charRun struct {
+	Char  string
+	Text  string
+	Class string
+}
+
+// Groups identical consecutive characters of a rendered row so the
+// template can color them without a span per tile
+func splitRuns(row string) []charRun {
+	runs := make([]charRun, 0)
+
+	for _, char := range row {
+		if last := len(runs) - 1; last >= 0 && runs[last].Char == string(char) {
+			runs[last].Text += string(char)
+			continue
+		}
+		runs = append(runs, charRun{Char: string(char), Text: string(char), Class: fmt.Sprintf("c%x", char)})
+	}
+	return runs
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Microsecond).String()
+}
+
+func serveCommand(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	address := fs.String("addr", "localhost:8023", "address the dashboard listens on")
+	workers := fs.Int("workers", runtime.NumCPU(), "amount of goroutines shared by the solvers, and of uploads solved at once")
+	timeout := fs.Duration("timeout", 30*time.Second, "longest time an uploaded input may be solved for")
+	memory := fs.Int("memory", 4096, "address space limit of the process solving an upload in MiB, none when 0")
+	year := registerYear(fs, false)
+	logs := logFlags{}
+	logs.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	logs.setup()
+	workpool.SetSize(*workers)
+
+	s := newServer(*year, newChildPool(*memory, *workers), *timeout)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleIndex)
+	mux.HandleFunc("/day/", s.handleDay)
+
+	slog.Info("dashboard listening", "url", "http://"+*address)
+	return http.ListenAndServe(*address, mux)
+}
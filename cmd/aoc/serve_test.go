@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDayActionsNeedPart(t *testing.T) {
+	s := newServer(2023, newChildPool(0, 1), 10*time.Second)
+	for _, request := range []struct{ method, target string }{
+		{http.MethodPost, "/day/1/run"},
+		{http.MethodPost, "/day/1/run?part=0"},
+		{http.MethodPost, "/day/1/run?part=3"},
+		{http.MethodGet, "/day/1/render?part=x"},
+	} {
+		recorder := httptest.NewRecorder()
+		s.handleDay(recorder, httptest.NewRequest(request.method, request.target, nil))
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("%s %s: status %d, expected %d", request.method, request.target, recorder.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func upload(t *testing.T, s *server, target, input string) string {
+	t.Helper()
+	body := bytes.Buffer{}
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("input", "input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Write([]byte(input))
+	form.Close()
+
+	request := httptest.NewRequest(http.MethodPost, target, &body)
+	request.Header.Set("Content-Type", form.FormDataContentType())
+	recorder := httptest.NewRecorder()
+	s.handleDay(recorder, request)
+	return recorder.Body.String()
+}
+
+// Uploads are solved apart from the dashboard, and stopped at the timeout
+func TestUpload(t *testing.T) {
+	s := newServer(2023, newChildPool(0, 1), 300*time.Millisecond)
+
+	if page := upload(t, s, "/day/16/upload?part=2", slowGrid()); !strings.Contains(page, "no answer within 300ms") {
+		t.Errorf("slow upload:\n%s", page)
+	}
+	if page := upload(t, s, "/day/1/upload?part=1", "1abc2"); !strings.Contains(page, `<span class="answer">12</span>`) {
+		t.Errorf("upload after the timeout:\n%s", page)
+	}
+}
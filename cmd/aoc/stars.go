@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"bta/aoc23/puzzle"
+)
+
+const (
+	eventDays = 25
+)
+
+const (
+	// An answer was accepted on the real input
+	partSolved = "solved"
+	// A solver is registered, but none of its answers was accepted
+	partUnsolved = "unsolved"
+	// No solver
+	partMissing = "missing"
+)
+
+var partMarks = map[string]string{
+	partSolved:   "★",
+	partUnsolved: "☆",
+	partMissing:  "·",
+}
+
+type dayStars struct {
+	Day   int       `json:"day"`
+	Title string    `json:"title,omitempty"`
+	Parts [2]string `json:"parts"`
+}
+
+type calendar struct {
+	Event int        `json:"event"`
+	Stars int        `json:"stars"`
+	Days  []dayStars `json:"days"`
+}
+
+func buildCalendar(year int, known *knownAnswers) calendar {
+	cal := calendar{Event: year, Days: make([]dayStars, 0, eventDays)}
+
+	for dayNumber := 1; dayNumber <= eventDays; dayNumber++ {
+		stars := dayStars{Day: dayNumber, Parts: [2]string{partMissing, partMissing}}
+		day, err := puzzle.Lookup(year, dayNumber)
+
+		if err == nil {
+			stars.Title = day.Title
+			for partNumber := 1; partNumber <= len(day.Parts); partNumber++ {
+				if _, solved := day.Part(partNumber); solved {
+					stars.Parts[partNumber-1] = partUnsolved
+				}
+			}
+		}
+		for _, answer := range known.Answers {
+			if answer.Year != year || answer.Day != dayNumber || answer.Source != "input" || answer.Part < 1 || answer.Part > len(stars.Parts) {
+				continue
+			}
+			if stars.Parts[answer.Part-1] == partUnsolved {
+				stars.Parts[answer.Part-1] = partSolved
+			}
+		}
+		for _, status := range stars.Parts {
+			if status == partSolved {
+				cal.Stars++
+			}
+		}
+		cal.Days = append(cal.Days, stars)
+	}
+	return cal
+}
+
+func starsCommand(args []string) error {
+	fs := flag.NewFlagSet("stars", flag.ContinueOnError)
+	year := registerYear(fs, false)
+	asJSON := fs.Bool("json", false, "print the calendar as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	known, err := loadKnownAnswers()
+	if err != nil {
+		return err
+	}
+	cal := buildCalendar(*year, known)
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(cal)
+	}
+
+	fmt.Printf("Advent of Code %d: %d/%d stars\n\n", cal.Event, cal.Stars, eventDays*2)
+	for _, day := range cal.Days {
+		fmt.Printf("day %2d  %s %s  %s\n", day.Day, partMarks[day.Parts[0]], partMarks[day.Parts[1]], day.Title)
+	}
+	legend := make([]string, 0, len(partMarks))
+	for _, status := range []string{partSolved, partUnsolved, partMissing} {
+		legend = append(legend, partMarks[status]+" "+status)
+	}
+	fmt.Printf("\n%s (solved: answer accepted in %s)\n", strings.Join(legend, "  "), knownAnswersFilename)
+	return nil
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"bta/aoc23/grid"
+	"bta/aoc23/puzzle"
+)
+
+// File polled by aoc watch
+type watchedFile struct {
+	path    string
+	modTime time.Time
+	size    int64
+}
+
+// Reports whether the file changed since the last call, the first call
+// always does
+func (f *watchedFile) changed() (bool, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return false, err
+	}
+	if info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return false, nil
+	}
+	f.modTime, f.size = info.ModTime(), info.Size()
+	return true, nil
+}
+
+// The input, as a grid file too, or its sealed version when only that one is
+// there
+func watchedFiles(day puzzle.Day, inputPath string, withExamples bool) []*watchedFile {
+	files := []*watchedFile{{path: puzzle.InputFile(inputPath)}}
+	if withExamples {
+		files = append(files, &watchedFile{path: day.InstructionsPath()})
+	}
+	return files
+}
+
+// Answer of a part on the input or on an example block
+type watchCase struct {
+	label    string
+	expected string
+	puzzle.Result
+}
+
+func watchCases(day puzzle.Day, parts []int, inputPath string, palette grid.Palette, opts puzzle.Options, withExamples bool) []watchCase {
+	cases := make([]watchCase, 0)
+	input, inputErr := readInputFile(day, inputPath, palette)
+
+	for _, part := range parts {
+		label := fmt.Sprintf("part %d", part)
+		if inputErr != nil {
+			cases = append(cases, watchCase{label: label, Result: puzzle.Result{Year: day.Year, Day: day.Number, Part: part, Err: inputErr}})
+		} else {
+			cases = append(cases, watchCase{label: label, Result: day.Run(part, input, opts)})
+		}
+	}
+	if !withExamples {
+		return cases
+	}
+
+	// The instructions are read again too, an edited example block is solved
+	// with the new content
+	examples, err := day.Examples()
+	for _, part := range parts {
+		p, _ := day.Part(part)
+
+		for _, example := range p.Examples {
+			c := watchCase{
+				label:    fmt.Sprintf("part %d example %d", part, example.Block),
+				expected: example.Answer,
+				Result:   puzzle.Result{Year: day.Year, Day: day.Number, Part: part},
+			}
+			switch {
+			case err != nil:
+				c.Err = err
+			case example.Block >= len(examples):
+				c.Err = fmt.Errorf("instructions.txt has only %d example blocks", len(examples))
+			default:
+				c.Result = day.Run(part, []byte(examples[example.Block]), day.DefaultOptions())
+			}
+			cases = append(cases, c)
+		}
+	}
+	return cases
+}
+
+// Prints the answers, and how they and their timing changed since the
+// previous run
+func printWatchDiff(previous map[string]watchCase, cases []watchCase) {
+	for _, c := range cases {
+		line := fmt.Sprintf("%-20s", c.label+":")
+		before, seen := previous[c.label]
+
+		if c.Err != nil {
+			line += fmt.Sprintf(" error: %v", c.Err)
+		} else {
+			line += " " + c.Answer
+			switch {
+			case !seen:
+			case before.Err != nil:
+				line += " (was an error)"
+			case before.Answer != c.Answer:
+				line += fmt.Sprintf(" (was %s)", before.Answer)
+			default:
+				line += " (unchanged)"
+			}
+			line += fmt.Sprintf(" in %v", c.Duration)
+			if seen && before.Err == nil && before.Duration > 0 {
+				line += fmt.Sprintf(" (was %v, %+.0f%%)", before.Duration, 100*(float64(c.Duration)/float64(before.Duration)-1))
+			}
+		}
+		if c.expected != "" {
+			if c.Err == nil && c.Answer == c.expected {
+				line += " OK"
+			} else {
+				line += fmt.Sprintf(" FAIL: expected %s", c.expected)
+			}
+		}
+		fmt.Println(line)
+	}
+}
+
+func watchCommand(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	dayNumber := fs.Int("day", 0, "day to watch")
+	part := fs.Int("part", 0, "part to solve (1 or 2), both when 0")
+	withExamples := fs.Bool("examples", false, "also watch instructions.txt and solve the example blocks of the parts")
+	interval := fs.Duration("interval", 500*time.Millisecond, "time between two polls of the files")
+	inputs := inputFlags{}
+	inputs.register(fs)
+	logs := logFlags{}
+	logs.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	logs.setup()
+	if inputs.path == "-" || inputs.example >= 0 {
+		return fmt.Errorf("watch solves a file, use -examples to watch the example blocks")
+	}
+
+	day, err := puzzle.Lookup(inputs.year, *dayNumber)
+	if err != nil {
+		return err
+	}
+	opts, err := inputs.options(day)
+	if err != nil {
+		return err
+	}
+	parts := make([]int, 0, len(day.Parts))
+	for partNumber := 1; partNumber <= len(day.Parts); partNumber++ {
+		if _, solved := day.Part(partNumber); (*part != 0 && partNumber != *part) || (*part == 0 && !solved) {
+			continue
+		}
+		parts = append(parts, partNumber)
+	}
+
+	inputPath := inputs.path
+	if inputPath == "" {
+		// The embedded copy never changes
+		if !day.LocalInput() {
+			return fmt.Errorf("%s isn't there, watch from the repository or give -input", day.InputPath())
+		}
+		inputPath = day.InputPath()
+	}
+	files := watchedFiles(day, inputPath, *withExamples)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	previous := map[string]watchCase{}
+
+	fmt.Printf("watching day %d of %d, interrupt to stop\n", day.Number, day.Year)
+	for {
+		changed := make([]string, 0, len(files))
+		for _, file := range files {
+			// A missing file is reported by the solve, and watched until it is back
+			if fileChanged, err := file.changed(); fileChanged || (err != nil && file.size >= 0) {
+				changed = append(changed, file.path)
+				if err != nil {
+					file.size = -1
+				}
+			}
+		}
+
+		if len(changed) > 0 {
+			fmt.Printf("\n[%s] %v changed\n", time.Now().Format(time.TimeOnly), changed)
+			cases := watchCases(day, parts, inputPath, inputs.palette, opts, *withExamples)
+			printWatchDiff(previous, cases)
+			for _, c := range cases {
+				previous[c.label] = c
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
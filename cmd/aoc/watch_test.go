@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bta/aoc23/grid"
+	"bta/aoc23/puzzle"
+)
+
+// Grid files in the formats Load reads, '#' cells are black pixels
+var gridEncoders = map[string]func(t *testing.T, rows []string) []byte{
+	".csv": func(_ *testing.T, rows []string) []byte {
+		lines := make([]string, 0, len(rows))
+		for _, row := range rows {
+			lines = append(lines, strings.Join(strings.Split(row, ""), ","))
+		}
+		return []byte(strings.Join(lines, "\n"))
+	},
+	".json": func(t *testing.T, rows []string) []byte {
+		data, err := json.Marshal(rows)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	},
+	".png": func(t *testing.T, rows []string) []byte {
+		img := image.NewRGBA(image.Rect(0, 0, len(rows[0]), len(rows)))
+		for y, row := range rows {
+			for x := range row {
+				img.Set(x, y, map[byte]color.Color{'.': color.White, '#': color.Black}[row[x]])
+			}
+		}
+		data := bytes.Buffer{}
+		if err := png.Encode(&data, img); err != nil {
+			t.Fatal(err)
+		}
+		return data.Bytes()
+	},
+}
+
+// An edited grid file is seen as changed, and solved with its new cells
+func TestWatchGridInput(t *testing.T) {
+	day, err := puzzle.Lookup(2023, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edits := [][]string{{"#..", "..#"}, {"#...", "...#", "#..."}}
+
+	for extension, encode := range gridEncoders {
+		path := filepath.Join(t.TempDir(), "universe"+extension)
+		files := watchedFiles(day, path, false)
+
+		for index, rows := range edits {
+			if err := os.WriteFile(path, encode(t, rows), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if changed, err := files[0].changed(); err != nil || !changed {
+				t.Fatalf("%s edit %d: changed %v, %v", extension, index, changed, err)
+			}
+			expected := day.Run(1, []byte(strings.Join(rows, "\n")), day.DefaultOptions())
+			cases := watchCases(day, []int{1}, path, grid.DefaultPalette, day.DefaultOptions(), false)
+			if cases[0].Err != nil || cases[0].Answer != expected.Answer {
+				t.Errorf("%s edit %d: answer %s (%v), expected %s", extension, index, cases[0].Answer, cases[0].Err, expected.Answer)
+			}
+		}
+		if changed, err := files[0].changed(); err != nil || changed {
+			t.Errorf("%s: changed %v without an edit, %v", extension, changed, err)
+		}
+	}
+}
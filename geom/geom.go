@@ -0,0 +1,151 @@
+// Package geom holds the directions and vectors of the grid puzzles, y grows
+// downwards as in the inputs: Up is (0, -1).
+package geom
+
+import (
+	"fmt"
+	"image"
+)
+
+// Vec is a position or a move on a grid.
+type Vec struct {
+	X, Y int
+}
+
+// VecOf converts an image.Point.
+func VecOf(p image.Point) Vec {
+	return Vec{p.X, p.Y}
+}
+
+func (v Vec) Point() image.Point {
+	return image.Point{v.X, v.Y}
+}
+
+func (v Vec) Add(w Vec) Vec {
+	return Vec{v.X + w.X, v.Y + w.Y}
+}
+
+func (v Vec) Sub(w Vec) Vec {
+	return Vec{v.X - w.X, v.Y - w.Y}
+}
+
+func (v Vec) Mul(k int) Vec {
+	return Vec{v.X * k, v.Y * k}
+}
+
+// Move returns the position n steps away in direction d.
+func (v Vec) Move(d Dir, n int) Vec {
+	return v.Add(d.Vec().Mul(n))
+}
+
+// Dir is one of the 4 directions of a grid, they are numbered clockwise from
+// Up so that a Dir can index a [4]T.
+type Dir uint8
+
+const (
+	Up Dir = iota
+	Right
+	Down
+	Left
+)
+
+// Compass names of the directions
+const (
+	North = Up
+	East  = Right
+	South = Down
+	West  = Left
+)
+
+// Dirs lists the directions in their order.
+var Dirs = [4]Dir{Up, Right, Down, Left}
+
+var (
+	unitVecs = [4]Vec{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+	letters  = [4]byte{'U', 'R', 'D', 'L'}
+	arrows   = [4]byte{'^', '>', 'v', '<'}
+)
+
+// ParseDir reads a direction written as a letter (URDL or NESW, in any case)
+// or an arrow (^>v<).
+func ParseDir(r rune) (Dir, error) {
+	switch r {
+	case 'U', 'u', 'N', 'n', '^':
+		return Up, nil
+	case 'R', 'r', 'E', 'e', '>':
+		return Right, nil
+	case 'D', 'd', 'S', 's', 'v':
+		return Down, nil
+	case 'L', 'l', 'W', 'w', '<':
+		return Left, nil
+	}
+	return 0, fmt.Errorf("'%c' isn't a direction", r)
+}
+
+// DirOf returns the direction of a unit vector, ok is false for any other
+// vector.
+func DirOf(v Vec) (Dir, bool) {
+	for _, d := range Dirs {
+		if unitVecs[d] == v {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// Vec returns the unit vector of the direction.
+func (d Dir) Vec() Vec {
+	return unitVecs[d%4]
+}
+
+func (d Dir) Point() image.Point {
+	return d.Vec().Point()
+}
+
+// Turn returns the direction n quarter turns clockwise (counterclockwise when
+// n is negative).
+func (d Dir) Turn(n int) Dir {
+	return Dir(((int(d)+n)%4 + 4) % 4)
+}
+
+func (d Dir) Clockwise() Dir {
+	return d.Turn(1)
+}
+
+func (d Dir) CounterClockwise() Dir {
+	return d.Turn(-1)
+}
+
+func (d Dir) Reverse() Dir {
+	return d.Turn(2)
+}
+
+// Transpose swaps the axes: Right and Down, Left and Up. It is how a '\'
+// mirror reflects a move.
+func (d Dir) Transpose() Dir {
+	return Dir(3 - d%4)
+}
+
+// AntiTranspose is how a '/' mirror reflects a move: Right and Up, Left and
+// Down.
+func (d Dir) AntiTranspose() Dir {
+	return d.Transpose().Reverse()
+}
+
+func (d Dir) IsVertical() bool {
+	return d%2 == 0
+}
+
+func (d Dir) IsHorizontal() bool {
+	return d%2 == 1
+}
+
+// Arrow returns the direction drawn as ^>v<.
+func (d Dir) Arrow() byte {
+	return arrows[d%4]
+}
+
+// String returns the direction as U, R, D or L.
+func (d Dir) String() string {
+	return string(letters[d%4])
+}
@@ -0,0 +1,91 @@
+package geom
+
+import (
+	"image"
+	"testing"
+)
+
+func TestDirs(t *testing.T) {
+	tests := []struct {
+		dir                               Dir
+		vec                               Vec
+		clockwise, counterClockwise       Dir
+		reverse, transpose, antiTranspose Dir
+		vertical                          bool
+		arrow                             byte
+		name                              string
+	}{
+		{Up, Vec{0, -1}, Right, Left, Down, Left, Right, true, '^', "U"},
+		{Right, Vec{1, 0}, Down, Up, Left, Down, Up, false, '>', "R"},
+		{Down, Vec{0, 1}, Left, Right, Up, Right, Left, true, 'v', "D"},
+		{Left, Vec{-1, 0}, Up, Down, Right, Up, Down, false, '<', "L"},
+	}
+	for _, test := range tests {
+		d := test.dir
+		if d.Vec() != test.vec || d.Point() != (image.Point{test.vec.X, test.vec.Y}) {
+			t.Errorf("%v: vector %v, expected %v", d, d.Vec(), test.vec)
+		}
+		if got, ok := DirOf(test.vec); !ok || got != d {
+			t.Errorf("%v: DirOf(%v) = %v, %v", d, test.vec, got, ok)
+		}
+		if d.Clockwise() != test.clockwise || d.Turn(1) != test.clockwise || d.Turn(5) != test.clockwise || d.Turn(-3) != test.clockwise {
+			t.Errorf("%v: clockwise %v, expected %v", d, d.Clockwise(), test.clockwise)
+		}
+		if d.CounterClockwise() != test.counterClockwise || d.Turn(-1) != test.counterClockwise || d.Turn(-5) != test.counterClockwise || d.Turn(3) != test.counterClockwise {
+			t.Errorf("%v: counterclockwise %v, expected %v", d, d.CounterClockwise(), test.counterClockwise)
+		}
+		if d.Reverse() != test.reverse || d.Turn(2) != test.reverse || d.Turn(-2) != test.reverse || d.Turn(0) != d || d.Turn(4) != d {
+			t.Errorf("%v: reverse %v, expected %v", d, d.Reverse(), test.reverse)
+		}
+		if d.Transpose() != test.transpose || d.Transpose().Transpose() != d {
+			t.Errorf("%v: transpose %v, expected %v", d, d.Transpose(), test.transpose)
+		}
+		if d.AntiTranspose() != test.antiTranspose || d.AntiTranspose().AntiTranspose() != d {
+			t.Errorf("%v: anti-transpose %v, expected %v", d, d.AntiTranspose(), test.antiTranspose)
+		}
+		// Transposing swaps the coordinates of the vector, anti-transposing
+		// also negates them
+		if tv := d.Transpose().Vec(); tv != (Vec{test.vec.Y, test.vec.X}) {
+			t.Errorf("%v: transposed vector %v", d, tv)
+		}
+		if av := d.AntiTranspose().Vec(); av != (Vec{-test.vec.Y, -test.vec.X}) {
+			t.Errorf("%v: anti-transposed vector %v", d, av)
+		}
+		if d.IsVertical() != test.vertical || d.IsHorizontal() == test.vertical {
+			t.Errorf("%v: vertical %v, expected %v", d, d.IsVertical(), test.vertical)
+		}
+		if d.Arrow() != test.arrow || d.String() != test.name {
+			t.Errorf("%v: arrow %c and name %s, expected %c and %s", d, d.Arrow(), d.String(), test.arrow, test.name)
+		}
+		if Dirs[d] != d {
+			t.Errorf("%v isn't at its index in Dirs", d)
+		}
+	}
+
+	for _, v := range []Vec{{0, 0}, {1, 1}, {2, 0}, {0, -2}} {
+		if d, ok := DirOf(v); ok {
+			t.Errorf("DirOf(%v) = %v, expected no direction", v, d)
+		}
+	}
+}
+
+func TestParseDir(t *testing.T) {
+	tests := map[Dir]string{
+		Up:    "UuNn^",
+		Right: "RrEe>",
+		Down:  "DdSsv",
+		Left:  "LlWw<",
+	}
+	for expected, runes := range tests {
+		for _, r := range runes {
+			if d, err := ParseDir(r); err != nil || d != expected {
+				t.Errorf("ParseDir(%c) = %v, %v, expected %v", r, d, err, expected)
+			}
+		}
+	}
+	for _, r := range "xX0 V" {
+		if d, err := ParseDir(r); err == nil {
+			t.Errorf("ParseDir(%c) = %v, expected an error", r, d)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Package graph searches graphs given by their neighbour functions, the
+// nodes are any comparable value (a point, a point and a direction...).
+package graph
+
+// Edge to a neighbour, with the cost of moving to it
+type Edge[N comparable] struct {
+	To   N
+	Cost int
+}
+
+// Paths records how a search reached the nodes: the node each one was reached
+// from (starts from themselves) and the cost to get there, the amount of
+// moves for the unweighted searches.
+type Paths[N comparable] struct {
+	From map[N]N
+	Cost map[N]int
+	// Goal node the search stopped on, when Found
+	End   N
+	Found bool
+}
+
+func newPaths[N comparable]() Paths[N] {
+	return Paths[N]{From: map[N]N{}, Cost: map[N]int{}}
+}
+
+// Reached reports whether the search got to node.
+func (p Paths[N]) Reached(node N) bool {
+	_, ok := p.From[node]
+	return ok
+}
+
+// Path returns the nodes from a start to node, nil when it wasn't reached.
+func (p Paths[N]) Path(node N) []N {
+	if !p.Reached(node) {
+		return nil
+	}
+	path := []N{node}
+
+	for from := p.From[node]; from != node; from = p.From[node] {
+		node = from
+		path = append(path, node)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// Dijkstra finds the cheapest paths from the starts, up to the first node
+// satisfying goal (every reachable node when goal is nil). Costs must not be
+// negative.
+func Dijkstra[N comparable](starts []N, edges func(N) []Edge[N], goal func(N) bool) Paths[N] {
+	return AStar(starts, edges, goal, func(N) int { return 0 })
+}
+
+// AStar is Dijkstra guided by heuristic, an estimation of the cost left to
+// reach a goal. The paths are still the cheapest as long as it is consistent:
+// it never overestimates, and doesn't drop by more than the cost of an edge.
+//
+// Nodes aren't updated in the queue when a cheaper path to them shows up
+// (decrease-key), they are pushed again and the stale entries are skipped
+// when popped (lazy deletion).
+func AStar[N comparable](starts []N, edges func(N) []Edge[N], goal func(N) bool, heuristic func(N) int) Paths[N] {
+	paths, queue, settled := newPaths[N](), PriorityQueue[N]{}, map[N]bool{}
+
+	for _, start := range starts {
+		paths.From[start], paths.Cost[start] = start, 0
+		queue.BetterPush(start, heuristic(start))
+	}
+	for len(queue) > 0 {
+		node, _ := queue.BetterPop()
+
+		if settled[node] {
+			continue
+		}
+		settled[node] = true
+		if goal != nil && goal(node) {
+			paths.End, paths.Found = node, true
+			return paths
+		}
+		for _, edge := range edges(node) {
+			cost := paths.Cost[node] + edge.Cost
+			if known, seen := paths.Cost[edge.To]; settled[edge.To] || (seen && known <= cost) {
+				continue
+			}
+			paths.From[edge.To], paths.Cost[edge.To] = node, cost
+			queue.BetterPush(edge.To, cost+heuristic(edge.To))
+		}
+	}
+	return paths
+}
+
+// BFS finds the paths with the fewest moves from the starts, up to the first
+// node satisfying goal (every reachable node when goal is nil).
+func BFS[N comparable](starts []N, neighbours func(N) []N, goal func(N) bool) Paths[N] {
+	paths, queue := newPaths[N](), make([]N, 0, len(starts))
+
+	for _, start := range starts {
+		if !paths.Reached(start) {
+			paths.From[start], paths.Cost[start] = start, 0
+			queue = append(queue, start)
+		}
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		if goal != nil && goal(node) {
+			paths.End, paths.Found = node, true
+			return paths
+		}
+		for _, next := range neighbours(node) {
+			if !paths.Reached(next) {
+				paths.From[next], paths.Cost[next] = node, paths.Cost[node]+1
+				queue = append(queue, next)
+			}
+		}
+	}
+	return paths
+}
+
+// DFS explores the nodes depth first from the starts, up to the first node
+// satisfying goal (every reachable node when goal is nil). The path it finds
+// isn't the shortest, the costs are the depths in the search tree.
+func DFS[N comparable](starts []N, neighbours func(N) []N, goal func(N) bool) Paths[N] {
+	paths, stack, visited := newPaths[N](), make([]N, 0, len(starts)), map[N]bool{}
+
+	// Pushed in reverse so that the first start, and the first neighbours,
+	// are explored first
+	for i := len(starts) - 1; i >= 0; i-- {
+		paths.From[starts[i]], paths.Cost[starts[i]] = starts[i], 0
+		stack = append(stack, starts[i])
+	}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if visited[node] {
+			continue
+		}
+		visited[node] = true
+		if goal != nil && goal(node) {
+			paths.End, paths.Found = node, true
+			return paths
+		}
+		next := neighbours(node)
+		for i := len(next) - 1; i >= 0; i-- {
+			// The latest push of a node is popped first, it records the
+			// parent; starts keep being their own
+			if from, reached := paths.From[next[i]]; !visited[next[i]] && !(reached && from == next[i]) {
+				paths.From[next[i]], paths.Cost[next[i]] = node, paths.Cost[node]+1
+				stack = append(stack, next[i])
+			}
+		}
+	}
+	return paths
+}
+
+// Reachable returns the set of the nodes reachable from the starts, starts
+// included.
+func Reachable[N comparable](starts []N, neighbours func(N) []N) map[N]bool {
+	reached, stack := map[N]bool{}, make([]N, 0, len(starts))
+
+	for _, start := range starts {
+		if !reached[start] {
+			reached[start] = true
+			stack = append(stack, start)
+		}
+	}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for _, next := range neighbours(node) {
+			if !reached[next] {
+				reached[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+	return reached
+}
@@ -0,0 +1,218 @@
+package graph
+
+import (
+	"image"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// '#' are walls, digits are the cost of entering a tile ('.' costs 1)
+var maze = strings.Split(`S9...E
+.####.
+......`, "\n")
+
+func find(grid []string, tile byte) image.Point {
+	for y, row := range grid {
+		if x := strings.IndexByte(row, tile); x >= 0 {
+			return image.Point{x, y}
+		}
+	}
+	panic("no tile " + string(tile))
+}
+
+func neighbours(grid []string) func(image.Point) []image.Point {
+	return func(p image.Point) []image.Point {
+		next := make([]image.Point, 0, 4)
+		for _, dir := range []image.Point{{1, 0}, {0, 1}, {-1, 0}, {0, -1}} {
+			n := p.Add(dir)
+			if n.Y >= 0 && n.Y < len(grid) && n.X >= 0 && n.X < len(grid[n.Y]) && grid[n.Y][n.X] != '#' {
+				next = append(next, n)
+			}
+		}
+		return next
+	}
+}
+
+func edges(grid []string) func(image.Point) []Edge[image.Point] {
+	return func(p image.Point) []Edge[image.Point] {
+		next := make([]Edge[image.Point], 0, 4)
+		for _, n := range neighbours(grid)(p) {
+			cost := 1
+			if tile := grid[n.Y][n.X]; tile >= '0' && tile <= '9' {
+				cost = int(tile - '0')
+			}
+			next = append(next, Edge[image.Point]{To: n, Cost: cost})
+		}
+		return next
+	}
+}
+
+func manhattan(to image.Point) func(image.Point) int {
+	return func(p image.Point) int {
+		d := p.Sub(to)
+		return max(d.X, -d.X) + max(d.Y, -d.Y)
+	}
+}
+
+// Checks that the path goes from a start to end through neighbours
+func checkPath(t *testing.T, path []image.Point, start, end image.Point, grid []string) {
+	t.Helper()
+	if len(path) == 0 || path[0] != start || path[len(path)-1] != end {
+		t.Fatalf("path %v doesn't go from %v to %v", path, start, end)
+	}
+	for i := 1; i < len(path); i++ {
+		if !slices.Contains(neighbours(grid)(path[i-1]), path[i]) {
+			t.Fatalf("path %v jumps from %v to %v", path, path[i-1], path[i])
+		}
+	}
+}
+
+func pathCost(grid []string, path []image.Point) int {
+	cost := 0
+	for _, p := range path[1:] {
+		if tile := grid[p.Y][p.X]; tile >= '0' && tile <= '9' {
+			cost += int(tile - '0')
+		} else {
+			cost++
+		}
+	}
+	return cost
+}
+
+func TestPriorityQueue(t *testing.T) {
+	queue := PriorityQueue[string]{}
+	for i, value := range []string{"c", "a", "d", "b"} {
+		queue.BetterPush(value, []int{3, 1, 4, 2}[i])
+	}
+	popped := ""
+	for len(queue) > 0 {
+		value, _ := queue.BetterPop()
+		popped += value
+	}
+	if popped != "abcd" {
+		t.Fatalf("popped %s", popped)
+	}
+}
+
+func TestBFS(t *testing.T) {
+	start, end := find(maze, 'S'), find(maze, 'E')
+	paths := BFS([]image.Point{start}, neighbours(maze), func(p image.Point) bool { return p == end })
+
+	if !paths.Found || paths.End != end {
+		t.Fatalf("end not found")
+	}
+	path := paths.Path(end)
+	checkPath(t, path, start, end, maze)
+	if paths.Cost[end] != 5 || len(path) != 6 {
+		t.Fatalf("%d moves, expected 5: %v", paths.Cost[end], path)
+	}
+	if !slices.Contains(path, image.Point{1, 0}) {
+		t.Fatalf("the fewest moves go through the 9: %v", path)
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	start, end := find(maze, 'S'), find(maze, 'E')
+	paths := Dijkstra([]image.Point{start}, edges(maze), func(p image.Point) bool { return p == end })
+
+	if !paths.Found {
+		t.Fatalf("end not found")
+	}
+	path := paths.Path(end)
+	checkPath(t, path, start, end, maze)
+	// The 9 costs more than going around it
+	if paths.Cost[end] != 9 || pathCost(maze, path) != 9 {
+		t.Fatalf("cost %d, expected 9: %v", paths.Cost[end], path)
+	}
+	if slices.Contains(path, image.Point{1, 0}) {
+		t.Fatalf("the cheapest path avoids the 9: %v", path)
+	}
+}
+
+func TestDijkstraDecreasedCost(t *testing.T) {
+	// b is first reached through its expensive edge, then through c
+	graph := map[string][]Edge[string]{
+		"a": {{To: "b", Cost: 10}, {To: "c", Cost: 1}},
+		"c": {{To: "b", Cost: 1}},
+		"b": {{To: "d", Cost: 1}},
+	}
+	paths := Dijkstra([]string{"a"}, func(n string) []Edge[string] { return graph[n] }, nil)
+
+	if paths.Cost["d"] != 3 || !slices.Equal(paths.Path("d"), []string{"a", "c", "b", "d"}) {
+		t.Fatalf("cost %d through %v", paths.Cost["d"], paths.Path("d"))
+	}
+}
+
+func TestAStar(t *testing.T) {
+	start, end := find(maze, 'S'), find(maze, 'E')
+	paths := AStar([]image.Point{start}, edges(maze), func(p image.Point) bool { return p == end }, manhattan(end))
+	reference := Dijkstra([]image.Point{start}, edges(maze), func(p image.Point) bool { return p == end })
+
+	if !paths.Found || paths.Cost[end] != reference.Cost[end] {
+		t.Fatalf("cost %d, expected %d", paths.Cost[end], reference.Cost[end])
+	}
+	checkPath(t, paths.Path(end), start, end, maze)
+	if len(paths.From) > len(reference.From) {
+		t.Fatalf("A* reached %d nodes, more than Dijkstra's %d", len(paths.From), len(reference.From))
+	}
+}
+
+func TestDFS(t *testing.T) {
+	start, end := find(maze, 'S'), find(maze, 'E')
+	paths := DFS([]image.Point{start}, neighbours(maze), func(p image.Point) bool { return p == end })
+
+	if !paths.Found {
+		t.Fatalf("end not found")
+	}
+	path := paths.Path(end)
+	checkPath(t, path, start, end, maze)
+	if paths.Cost[end] != len(path)-1 {
+		t.Fatalf("depth %d for a path of %d moves", paths.Cost[end], len(path)-1)
+	}
+}
+
+func TestSeveralStarts(t *testing.T) {
+	end := find(maze, 'E')
+	starts := []image.Point{find(maze, 'S'), {5, 2}}
+	paths := BFS(starts, neighbours(maze), func(p image.Point) bool { return p == end })
+
+	if path := paths.Path(end); path[0] != starts[1] || paths.Cost[end] != 2 {
+		t.Fatalf("%d moves through %v, expected 2 from %v", paths.Cost[end], path, starts[1])
+	}
+	// A start met again keeps being its own origin
+	paths = DFS(starts, neighbours(maze), nil)
+	for _, start := range starts {
+		if path := paths.Path(start); len(path) != 1 {
+			t.Fatalf("path to the start %v: %v", start, path)
+		}
+	}
+}
+
+func TestUnreachable(t *testing.T) {
+	grid := []string{"S#E"}
+	start, end := find(grid, 'S'), find(grid, 'E')
+	goal := func(p image.Point) bool { return p == end }
+
+	for name, paths := range map[string]Paths[image.Point]{
+		"BFS":      BFS([]image.Point{start}, neighbours(grid), goal),
+		"DFS":      DFS([]image.Point{start}, neighbours(grid), goal),
+		"Dijkstra": Dijkstra([]image.Point{start}, edges(grid), goal),
+	} {
+		if paths.Found || paths.Reached(end) || paths.Path(end) != nil {
+			t.Errorf("%s reached the end", name)
+		}
+	}
+}
+
+func TestReachable(t *testing.T) {
+	reached := Reachable([]image.Point{find(maze, 'S')}, neighbours(maze))
+	walls := strings.Count(strings.Join(maze, ""), "#")
+
+	if len(reached) != len(maze)*len(maze[0])-walls {
+		t.Fatalf("reached %d tiles, expected every one but the %d walls", len(reached), walls)
+	}
+	if reached[image.Point{2, 1}] {
+		t.Fatalf("reached a wall")
+	}
+}
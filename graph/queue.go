@@ -0,0 +1,46 @@
+package graph
+
+import "container/heap"
+
+type QueueItem[T any] struct {
+	Value    T
+	Priority int
+}
+
+// PriorityQueue is a min-heap of values, it implements heap.Interface and
+// BetterPush/BetterPop spare the type assertions.
+type PriorityQueue[T any] []QueueItem[T]
+
+func (q PriorityQueue[_]) Len() int {
+	return len(q)
+}
+
+func (q PriorityQueue[_]) Less(i, j int) bool {
+	return q[i].Priority < q[j].Priority
+}
+
+func (q PriorityQueue[_]) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *PriorityQueue[T]) Push(item any) {
+	(*q) = append(*q, item.(QueueItem[T]))
+}
+
+func (q *PriorityQueue[_]) Pop() any {
+	old := *q
+	n := len(old)
+	item := (*q)[n-1]
+	*q = (*q)[0 : n-1]
+	return item
+}
+
+func (q *PriorityQueue[T]) BetterPush(item T, priority int) {
+	heap.Push(q, QueueItem[T]{Value: item, Priority: priority})
+}
+
+func (q *PriorityQueue[T]) BetterPop() (T, int) {
+	val := heap.Pop(q).(QueueItem[T])
+
+	return val.Value, val.Priority
+}
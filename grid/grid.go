@@ -0,0 +1,192 @@
+// Package grid loads the grids of the grid days from CSV, JSON and PNG files,
+// as the rows of their text format.
+package grid
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Palette maps the colours of a PNG grid to cells, alpha is ignored.
+type Palette map[color.RGBA]byte
+
+// DefaultPalette reads white pixels as '.' and black ones as '#'.
+var DefaultPalette = Palette{
+	{R: 0xff, G: 0xff, B: 0xff, A: 0xff}: '.',
+	{R: 0x00, G: 0x00, B: 0x00, A: 0xff}: '#',
+}
+
+// ParsePalette reads comma separated rrggbb=c entries, eg: "ffffff=.,000000=#".
+func ParsePalette(spec string) (Palette, error) {
+	palette := Palette{}
+
+	for _, entry := range strings.Split(spec, ",") {
+		hex, cell, found := strings.Cut(strings.TrimPrefix(strings.TrimSpace(entry), "#"), "=")
+		if !found || len(hex) != 6 || len(cell) != 1 {
+			return nil, fmt.Errorf("palette entry '%s' isn't written rrggbb=c", entry)
+		}
+		rgb, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("palette entry '%s': %v", entry, err)
+		}
+		palette[color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xff}] = cell[0]
+	}
+	return palette, nil
+}
+
+// Set parses spec, so that a palette can be a flag.
+func (p *Palette) Set(spec string) error {
+	palette, err := ParsePalette(spec)
+	if err != nil {
+		return err
+	}
+	*p = palette
+	return nil
+}
+
+func (p Palette) String() string {
+	entries := make([]string, 0, len(p))
+	for c, cell := range p {
+		entries = append(entries, fmt.Sprintf("%02x%02x%02x=%c", c.R, c.G, c.B, cell))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
+
+// IsGridFile reports whether Load reads the file, from its extension.
+func IsGridFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv", ".json", ".png":
+		return true
+	}
+	return false
+}
+
+// Load converts a grid to the text format, by the file extension.
+func Load(name string, data []byte, palette Palette) ([]byte, error) {
+	var rows []string
+	var err error
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		rows, err = FromCSV(data)
+	case ".json":
+		rows, err = FromJSON(data)
+	case ".png":
+		rows, err = FromPNG(data, palette)
+	default:
+		return nil, fmt.Errorf("'%s' isn't a CSV, JSON or PNG grid", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("grid '%s': %v", name, err)
+	}
+	return []byte(strings.Join(rows, "\n")), nil
+}
+
+// Rows of a grid must be as wide as the first one
+func checkWidth(rows []string) ([]string, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("the grid is empty")
+	}
+	for index, row := range rows {
+		if len(row) != len(rows[0]) {
+			return nil, fmt.Errorf("row %d is %d cells wide, the first one is %d", index+1, len(row), len(rows[0]))
+		}
+	}
+	return rows, nil
+}
+
+// FromCSV reads a single character cell per field.
+func FromCSV(data []byte) ([]string, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]string, 0, len(records))
+
+	for y, record := range records {
+		row := make([]byte, 0, len(record))
+		for x, field := range record {
+			if len(field) != 1 {
+				return nil, fmt.Errorf("cell (%d, %d) holds '%s', cells are a single character", x, y, field)
+			}
+			row = append(row, field[0])
+		}
+		rows = append(rows, string(row))
+	}
+	return checkWidth(rows)
+}
+
+// FromJSON reads an array of rows, strings or arrays of cells (characters or
+// digits).
+func FromJSON(data []byte) ([]string, error) {
+	raw := []json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	rows := make([]string, 0, len(raw))
+
+	for y, rawRow := range raw {
+		text := ""
+		if json.Unmarshal(rawRow, &text) == nil {
+			rows = append(rows, text)
+			continue
+		}
+		cells := []any{}
+		if err := json.Unmarshal(rawRow, &cells); err != nil {
+			return nil, fmt.Errorf("row %d is neither a string nor an array of cells", y+1)
+		}
+		row := make([]byte, 0, len(cells))
+		for x, cell := range cells {
+			switch value := cell.(type) {
+			case string:
+				if len(value) != 1 {
+					return nil, fmt.Errorf("cell (%d, %d) holds '%s', cells are a single character", x, y, value)
+				}
+				row = append(row, value[0])
+			case float64:
+				if value < 0 || value > 9 || value != float64(int(value)) {
+					return nil, fmt.Errorf("cell (%d, %d) holds %v, number cells are a digit", x, y, value)
+				}
+				row = append(row, '0'+byte(value))
+			default:
+				return nil, fmt.Errorf("cell (%d, %d) holds %v, cells are a character or a digit", x, y, cell)
+			}
+		}
+		rows = append(rows, string(row))
+	}
+	return checkWidth(rows)
+}
+
+// FromPNG reads a cell per pixel through the palette.
+func FromPNG(data []byte, palette Palette) ([]string, error) {
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	bounds := img.Bounds()
+	rows := make([]string, 0, bounds.Dy())
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row := make([]byte, 0, bounds.Dx())
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			cell, found := palette[color.RGBA{R: c.R, G: c.G, B: c.B, A: 0xff}]
+			if !found {
+				return nil, fmt.Errorf("pixel %v is #%02x%02x%02x, which isn't in the palette %v", image.Pt(x, y), c.R, c.G, c.B, palette)
+			}
+			row = append(row, cell)
+		}
+		rows = append(rows, string(row))
+	}
+	return checkWidth(rows)
+}
@@ -0,0 +1,75 @@
+package grid
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+var rows = []string{
+	"#.O.",
+	"..#O",
+	"O#..",
+}
+
+func TestLoad(t *testing.T) {
+	palette, err := ParsePalette("ffffff=.,#000000=#,ff0000=O")
+	if err != nil {
+		t.Fatal(err)
+	}
+	colors := map[byte]color.Color{'.': color.White, '#': color.Black, 'O': color.RGBA{R: 0xff, A: 0xff}}
+	img := image.NewRGBA(image.Rect(0, 0, len(rows[0]), len(rows)))
+	for y, row := range rows {
+		for x := range row {
+			img.Set(x, y, colors[row[x]])
+		}
+	}
+	pngData := bytes.Buffer{}
+	if err := png.Encode(&pngData, img); err != nil {
+		t.Fatal(err)
+	}
+
+	inputs := map[string][]byte{
+		"grid.csv":  []byte("#,.,O,.\n.,.,#,O\nO,#,.,.\n"),
+		"grid.json": []byte(`["#.O.", "..#O", ["O", "#", ".", "."]]`),
+		"grid.png":  pngData.Bytes(),
+	}
+	for name, data := range inputs {
+		text, err := Load(name, data, palette)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+		} else if string(text) != strings.Join(rows, "\n") {
+			t.Errorf("%s: got\n%s\nexpected\n%s", name, text, strings.Join(rows, "\n"))
+		}
+	}
+}
+
+func TestLoadDigits(t *testing.T) {
+	text, err := Load("digits.json", []byte("[[2, 4, 1], [3, 2, 1]]"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(text) != "241\n321" {
+		t.Errorf("got %q", text)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	inputs := map[string][]byte{
+		"ragged.csv": []byte("#,.\n#\n"),
+		"wide.csv":   []byte("#,..\n"),
+		"cell.json":  []byte(`[[10, 1]]`),
+		"row.json":   []byte(`[{"row": "#."}]`),
+	}
+	for name, data := range inputs {
+		if _, err := Load(name, data, DefaultPalette); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := ParsePalette("fff=."); err == nil {
+		t.Errorf("short colour: expected an error")
+	}
+}
@@ -0,0 +1,90 @@
+// Command day12memo is an alternative day12 solver, counting the
+// arrangements with a memoized recursion instead of day12's state machine.
+// It is built as a plugin and compared to the built-in solver:
+//
+//	go build -buildmode=plugin -o day12memo.so ./plugins/day12memo
+//	go run ./cmd/aoc compare -plugin day12memo.so
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"bta/aoc23/puzzle"
+)
+
+var (
+	Year = 2023
+	Day  = 12
+)
+
+func Part1(input []byte, _ puzzle.Options) (any, error) {
+	return sumArrangements(input, 1)
+}
+
+func Part2(input []byte, opts puzzle.Options) (any, error) {
+	return sumArrangements(input, opts.Get("unfold"))
+}
+
+func sumArrangements(input []byte, unfold int) (int, error) {
+	total := 0
+
+	for index, line := range strings.Split(string(input), "\n") {
+		springs, groupsText, found := strings.Cut(line, " ")
+		if !found {
+			return 0, &puzzle.ParseError{Line: index + 1, Text: line, Err: fmt.Errorf("expected springs and groups")}
+		}
+		groups := make([]int, 0)
+		for _, group := range strings.Split(groupsText, ",") {
+			size, err := strconv.Atoi(group)
+			if err != nil {
+				return 0, &puzzle.ParseError{Line: index + 1, Text: line, Err: err}
+			}
+			groups = append(groups, size)
+		}
+
+		unfoldedGroups := make([]int, 0, len(groups)*unfold)
+		for copyIndex := 0; copyIndex < unfold; copyIndex++ {
+			unfoldedGroups = append(unfoldedGroups, groups...)
+		}
+		total += arrangements(strings.Repeat(springs+"?", unfold-1)+springs, unfoldedGroups)
+	}
+	return total, nil
+}
+
+// Ways to place the groups from springs[i], starting with groups[g]
+func arrangements(springs string, groups []int) int {
+	memo := map[[2]int]int{}
+	var count func(i, g int) int
+
+	count = func(i, g int) int {
+		if g == len(groups) {
+			if strings.Contains(springs[i:], "#") {
+				return 0
+			}
+			return 1
+		}
+		if i >= len(springs) {
+			return 0
+		}
+		if known, ok := memo[[2]int{i, g}]; ok {
+			return known
+		}
+
+		total := 0
+		if springs[i] != '#' {
+			total += count(i+1, g)
+		}
+		end := i + groups[g]
+		if end <= len(springs) && !strings.Contains(springs[i:end], ".") && (end == len(springs) || springs[end] != '#') {
+			total += count(min(end+1, len(springs)), g+1)
+		}
+		memo[[2]int{i, g}] = total
+		return total
+	}
+	return count(0, 0)
+}
+
+// The package is only meant to be built with -buildmode=plugin
+func main() {}
@@ -0,0 +1,30 @@
+// Package proptest runs testing/quick property checks from a seed printed
+// with every failure, so that a failing case found at random can be run
+// again: go test -run '^TestName$' -seed N. The number of cases checked is
+// the -quickchecks flag of testing/quick.
+package proptest
+
+import (
+	"flag"
+	"math/rand"
+	"testing"
+	"testing/quick"
+	"time"
+)
+
+var seed = flag.Int64("seed", 0, "seed of the property checks, a new one for each check when 0")
+
+// Check calls f with random arguments as quick.Check does, the arguments are
+// generated by the Generate method of their type when they have one.
+func Check(t *testing.T, f any) {
+	t.Helper()
+	checkSeed := *seed
+	if checkSeed == 0 {
+		checkSeed = time.Now().UnixNano()
+	}
+
+	config := &quick.Config{Rand: rand.New(rand.NewSource(checkSeed))}
+	if err := quick.Check(f, config); err != nil {
+		t.Errorf("%v\nreproduce with: go test -run '^%s$' -seed %d", err, t.Name(), checkSeed)
+	}
+}
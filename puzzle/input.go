@@ -0,0 +1,247 @@
+package puzzle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+const (
+	instructionsFilename = "instructions.txt"
+)
+
+// Root is the directory holding the day directories.
+var Root = "."
+
+// InputPath returns the path of the real puzzle input of the day.
+func (d Day) InputPath() string {
+	return filepath.Join(Root, d.Dir(), d.Input)
+}
+
+// InstructionsPath returns the path of the puzzle statement of the day.
+func (d Day) InstructionsPath() string {
+	return filepath.Join(Root, d.Dir(), instructionsFilename)
+}
+
+// Opens a file of the day directory, or its embedded copy when the directory
+// isn't there. Sealed versions are decrypted on the fly.
+func (d Day) openFile(name string) (io.ReadCloser, error) {
+	path := filepath.Join(Root, d.Dir(), name)
+	file, err := OpenInput(path)
+	if !errors.Is(err, fs.ErrNotExist) || d.Files == nil {
+		return file, err
+	}
+	embedded, embeddedErr := openInputFS(d.Files, name)
+	if embeddedErr != nil {
+		if errors.Is(embeddedErr, fs.ErrNotExist) {
+			// Not embedded either, the file in the directory is the expected one
+			return nil, err
+		}
+		return nil, fmt.Errorf("embedded copy of %s: %w", path, embeddedErr)
+	}
+	return embedded, nil
+}
+
+func (d Day) readFile(name string) ([]byte, error) {
+	reader, err := d.openFile(name)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// LocalInput reports whether the real input of the day is read from the day
+// directory, rather than from the copy embedded in the binary.
+func (d Day) LocalInput() bool {
+	_, err := os.Stat(InputFile(d.InputPath()))
+	return err == nil
+}
+
+// OpenInput opens the real input of the day, see openFile.
+func (d Day) OpenInput() (io.ReadCloser, error) {
+	reader, err := d.openFile(d.Input)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open input file '%s'\n%w", d.InputPath(), err)
+	}
+	return reader, nil
+}
+
+// ReadInput reads the real input of the day, see openFile.
+func (d Day) ReadInput() ([]byte, error) {
+	file, err := d.readFile(d.Input)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read input file '%s'\n%w", d.InputPath(), err)
+	}
+	return Normalize(file), nil
+}
+
+// Every solver splits its input on '\n', a trailing newline would make them
+// parse an extra empty line.
+func Normalize(input []byte) []byte {
+	input = bytes.ReplaceAll(input, []byte("\r\n"), []byte("\n"))
+	return bytes.TrimRight(input, "\n")
+}
+
+// Normalizer writes what Normalize returns for the bytes written to it,
+// without holding them: the newlines are only written once a character
+// follows them. Close flushes a trailing '\r'.
+type Normalizer struct {
+	w io.Writer
+	// '\r' not written yet, in case a '\n' follows
+	carriageReturn bool
+	// Newlines not written yet, in case they are trailing
+	newlines int
+}
+
+func NewNormalizer(w io.Writer) *Normalizer {
+	return &Normalizer{w: w}
+}
+
+func (n *Normalizer) Write(p []byte) (int, error) {
+	out := make([]byte, 0, len(p))
+
+	for _, b := range p {
+		if n.carriageReturn {
+			n.carriageReturn = false
+			if b == '\n' {
+				n.newlines++
+				continue
+			}
+			out = n.flush(out, '\r')
+		}
+		switch b {
+		case '\r':
+			n.carriageReturn = true
+		case '\n':
+			n.newlines++
+		default:
+			out = n.flush(out, b)
+		}
+	}
+	if _, err := n.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Appends the pending newlines, then b
+func (n *Normalizer) flush(out []byte, b byte) []byte {
+	for ; n.newlines > 0; n.newlines-- {
+		out = append(out, '\n')
+	}
+	return append(out, b)
+}
+
+func (n *Normalizer) Close() error {
+	if !n.carriageReturn {
+		return nil
+	}
+	n.carriageReturn = false
+	_, err := n.w.Write(n.flush(nil, '\r'))
+	return err
+}
+
+// ReadInput reads an input file, sealed inputs are decrypted on the fly.
+func ReadInput(path string) ([]byte, error) {
+	reader, err := OpenInput(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open input file '%s'\n%w", path, err)
+	}
+	defer reader.Close()
+	file, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read input file '%s'\n%v", path, err)
+	}
+	return Normalize(file), nil
+}
+
+// ParseError locates an error in the input, the line being the record
+// number for comma separated inputs.
+type ParseError struct {
+	Line int
+	Text string
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseGrid splits the input of a grid day into its rows, which must be as
+// wide as the first one and only hold the given cells (any printable
+// character when cells is empty).
+func ParseGrid(input []byte, cells string) ([]string, error) {
+	if len(input) == 0 {
+		return nil, &ParseError{Line: 1, Err: fmt.Errorf("the grid is empty")}
+	}
+	rows := strings.Split(string(input), "\n")
+
+	for index, row := range rows {
+		if len(row) != len(rows[0]) {
+			return nil, &ParseError{Line: index + 1, Text: row, Err: fmt.Errorf("the grid rows are %d wide, this one is %d", len(rows[0]), len(row))}
+		}
+		for x := 0; x < len(row); x++ {
+			switch {
+			case cells == "" && (row[x] <= ' ' || row[x] > '~'):
+				return nil, &ParseError{Line: index + 1, Text: row, Err: fmt.Errorf("column %d holds %q, which isn't a printable character", x+1, row[x])}
+			case cells != "" && strings.IndexByte(cells, row[x]) < 0:
+				return nil, &ParseError{Line: index + 1, Text: row, Err: fmt.Errorf("column %d holds %q, cells are one of %q", x+1, row[x], cells)}
+			}
+		}
+	}
+	return rows, nil
+}
+
+// A line is considered as prose when it looks like a sentence: words ending
+// with a punctuation mark, and starting with a capital when there are only a
+// few of them ("For example:" is prose, "seed-to-soil map:" isn't). Quotes,
+// parenthesis and bullet lists are prose too.
+func isProse(line string) bool {
+	line = strings.TrimSpace(line)
+
+	if len(line) < 2 {
+		return false
+	}
+	first, last := line[:1], line[len(line)-1:]
+	switch {
+	case (first == "\"" && last == "\"") || (first == "(" && last == ")") || strings.HasPrefix(line, "- "):
+		return true
+	case !strings.Contains(line, " ") || !strings.ContainsAny(last, ".:?!)\""):
+		return false
+	}
+	return len(strings.Fields(line)) >= 5 || (last != ")" && unicode.IsUpper(rune(line[0])))
+}
+
+// ExtractExamples returns every preformatted block of the instructions, in
+// order. A block runs until the next prose line, so blank lines inside
+// examples (day05 maps, day13 patterns) are kept.
+func ExtractExamples(instructions []byte) []string {
+	blocks := make([]string, 0)
+
+	for _, paragraph := range ParseStatement(instructions) {
+		if paragraph.Kind == ExampleParagraph {
+			blocks = append(blocks, paragraph.Text)
+		}
+	}
+	return blocks
+}
+
+// Examples returns the example blocks of the day's instructions.txt.
+func (d Day) Examples() ([]string, error) {
+	file, err := d.readFile(instructionsFilename)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open instructions file\n%v", err)
+	}
+	return ExtractExamples(file), nil
+}
@@ -0,0 +1,87 @@
+package puzzle
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"bta/aoc23/proptest"
+)
+
+func TestEmbeddedInput(t *testing.T) {
+	encoded, err := NewInputKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, _ := hex.DecodeString(encoded)
+	sealed, err := Seal([]byte("sealed\n"), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(KeyEnv, encoded)
+	defer func(root string) { Root = root }(Root)
+	Root = t.TempDir()
+
+	day := Day{Year: 2023, Number: 1, Input: "input.txt", Files: fstest.MapFS{
+		"input.txt":        {Data: []byte("embedded\n")},
+		"instructions.txt": {Data: []byte("For example:\n\n    1abc2\n")},
+	}}
+	if input, err := day.ReadInput(); err != nil || string(input) != "embedded" || day.LocalInput() {
+		t.Fatalf("without the day directory: read %q, %v", input, err)
+	}
+	if examples, err := day.Examples(); err != nil || len(examples) != 1 {
+		t.Fatalf("examples of the embedded instructions: %q, %v", examples, err)
+	}
+
+	day.Files = fstest.MapFS{"input.txt" + SealedSuffix: {Data: sealed}}
+	if input, err := day.ReadInput(); err != nil || string(input) != "sealed" {
+		t.Fatalf("embedded sealed input: read %q, %v", input, err)
+	}
+
+	// The day directory wins over the binary
+	if err := os.MkdirAll(filepath.Join(Root, day.Dir()), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(day.InputPath(), []byte("local\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if input, err := day.ReadInput(); err != nil || string(input) != "local" || !day.LocalInput() {
+		t.Fatalf("with the day directory: read %q, %v", input, err)
+	}
+
+	day.Files = nil
+	if err := os.Remove(day.InputPath()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := day.ReadInput(); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("read a missing input: %v", err)
+	}
+}
+
+func TestNormalizer(t *testing.T) {
+	proptest.Check(t, func(chunks [][]byte) bool {
+		// Mostly line endings, which are what Normalize rewrites
+		for _, chunk := range chunks {
+			for index := range chunk {
+				chunk[index] = "\r\na"[chunk[index]%3]
+			}
+		}
+		normalized := bytes.Buffer{}
+		normalizer := NewNormalizer(&normalized)
+		for _, chunk := range chunks {
+			normalizer.Write(chunk)
+		}
+		normalizer.Close()
+
+		expected := Normalize(bytes.Join(chunks, nil))
+		if !bytes.Equal(normalized.Bytes(), expected) {
+			t.Logf("%q: wrote %q, expected %q", chunks, normalized.Bytes(), expected)
+			return false
+		}
+		return true
+	})
+}
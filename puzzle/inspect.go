@@ -0,0 +1,68 @@
+package puzzle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Inspector parses an input and reports structural facts about it (aoc
+// inspect), to size an input up before running a slow solver on it.
+type Inspector func(input []byte, opts Options) ([]Fact, error)
+
+// Fact is a measure of an input, its value is only ever displayed with fmt.
+type Fact struct {
+	Name  string
+	Value any
+}
+
+// Histogram counts the characters of an input.
+type Histogram map[byte]int
+
+// Add counts the characters of a line.
+func (h Histogram) Add(line string) {
+	for index := 0; index < len(line); index++ {
+		h[line[index]]++
+	}
+}
+
+// String lists the characters from the most to the least frequent, eg:
+// '.' 9801, '#' 99
+func (h Histogram) String() string {
+	chars := make([]byte, 0, len(h))
+	for char := range h {
+		chars = append(chars, char)
+	}
+	sort.Slice(chars, func(i, j int) bool {
+		if h[chars[i]] != h[chars[j]] {
+			return h[chars[i]] > h[chars[j]]
+		}
+		return chars[i] < chars[j]
+	})
+
+	counts := make([]string, 0, len(chars))
+	for _, char := range chars {
+		counts = append(counts, fmt.Sprintf("%q %d", char, h[char]))
+	}
+	return strings.Join(counts, ", ")
+}
+
+// GridFacts reports the size of a grid input, whose lines must all have the
+// same length, and its character histogram. It is the Inspector of the grid
+// days.
+func GridFacts(input []byte, _ Options) ([]Fact, error) {
+	rows, err := ParseGrid(Normalize(input), "")
+	if err != nil {
+		return nil, err
+	}
+	histogram := Histogram{}
+
+	for _, row := range rows {
+		histogram.Add(row)
+	}
+	return []Fact{
+		{Name: "grid", Value: fmt.Sprintf("%dx%d", len(rows[0]), len(rows))},
+		{Name: "cells", Value: len(rows[0]) * len(rows)},
+		{Name: "characters", Value: histogram},
+	}, nil
+}
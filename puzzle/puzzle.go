@@ -0,0 +1,258 @@
+// Package puzzle holds the registry every day registers its solvers into,
+// so that the aoc runner can list, run and display them.
+package puzzle
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// Solver computes the answer of a puzzle part from its raw input.
+// Answers are usually ints, they are only ever displayed with fmt.
+type Solver func(input []byte, opts Options) (any, error)
+
+// StreamSolver computes the answer of a puzzle part reading its input as it
+// goes, for inputs too large to be loaded at once.
+type StreamSolver func(input io.Reader, opts Options) (any, error)
+
+// Renderer draws the puzzle model as text rows, one string per grid line.
+type Renderer func(input []byte, opts Options) ([]string, error)
+
+// Explorer parses an input and returns the commands aoc repl offers to
+// inspect the parsed model, the commands share the model between calls.
+type Explorer func(input []byte, opts Options) ([]Command, error)
+
+type Command struct {
+	Name string
+	// Arguments description, eg: "<seed> <amount>"
+	Args  string
+	Usage string
+	Run   func(args []string, out io.Writer) error
+}
+
+// Option declares a tunable rule of a day, the runners expose it as a flag
+// and in options files. The options of a day are the schema their values are
+// checked against.
+type Option struct {
+	Name    string
+	Usage   string
+	Default int
+	// Least accepted value, and greatest one when Max isn't 0
+	Min, Max int
+}
+
+// Check returns an error when value is out of the option's bounds.
+func (o Option) Check(value int) error {
+	if value < o.Min || (o.Max != 0 && value > o.Max) {
+		if o.Max == 0 {
+			return fmt.Errorf("option '%s' must be at least %d, got %d", o.Name, o.Min, value)
+		}
+		return fmt.Errorf("option '%s' must be between %d and %d, got %d", o.Name, o.Min, o.Max, value)
+	}
+	return nil
+}
+
+// BigInt is declared by the days whose answers can overflow an int, they
+// switch to math/big on overflow and from the start when it is set to 1
+// (aoc run -bigint).
+var BigInt = Option{Name: "bigint", Usage: "use math/big for every computation when set to 1", Default: 0, Max: 1}
+
+// Options holds option values keyed by option name.
+type Options map[string]int
+
+// Example links an example block of instructions.txt to its expected answer.
+type Example struct {
+	// Index of the block in the list returned by ExtractExamples
+	Block  int
+	Answer string
+}
+
+// A part is solved by Solve or, when it can read its input line by line,
+// by Stream.
+type Part struct {
+	Solve    Solver
+	Stream   StreamSolver
+	Render   Renderer
+	Examples []Example
+}
+
+type Day struct {
+	// Event year, the days of a year live in its directory (2023/day05)
+	Year   int
+	Number int
+	Title  string
+	// Input filename, relative to the day directory
+	Input string
+	// Copies of the day directory files embedded at build time (the input
+	// and instructions.txt), read when the binary isn't run from the
+	// repository
+	Files fs.FS
+	// The input is a character grid, which the runners also read from CSV,
+	// JSON and PNG files (package grid)
+	Grid    bool
+	Options []Option
+	// Parts[0] is part one, a part without a solver isn't solved yet
+	Parts   [2]Part
+	Explore Explorer
+	Inspect Inspector
+}
+
+type dayKey struct {
+	year, number int
+}
+
+var registry = map[dayKey]Day{}
+
+// Register adds a day to the registry, it is meant to be called from init.
+func Register(day Day) {
+	if day.Year == 0 {
+		panic(fmt.Sprintf("puzzle: day %d registered without a year", day.Number))
+	}
+	key := dayKey{day.Year, day.Number}
+	if _, exists := registry[key]; exists {
+		panic(fmt.Sprintf("puzzle: day %d of %d registered twice", day.Number, day.Year))
+	}
+	registry[key] = day
+}
+
+// Days returns every registered day of every year, sorted by year and number.
+func Days() []Day {
+	days := make([]Day, 0, len(registry))
+
+	for _, day := range registry {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].Year != days[j].Year {
+			return days[i].Year < days[j].Year
+		}
+		return days[i].Number < days[j].Number
+	})
+	return days
+}
+
+// YearDays returns the registered days of a year, sorted by number.
+func YearDays(year int) []Day {
+	days := make([]Day, 0)
+
+	for _, day := range Days() {
+		if day.Year == year {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// Years returns the years having registered days, in order.
+func Years() []int {
+	years := make([]int, 0)
+
+	for _, day := range Days() {
+		if len(years) == 0 || years[len(years)-1] != day.Year {
+			years = append(years, day.Year)
+		}
+	}
+	return years
+}
+
+// LatestYear is the year the runners default to, 0 when no day is registered.
+func LatestYear() int {
+	years := Years()
+	if len(years) == 0 {
+		return 0
+	}
+	return years[len(years)-1]
+}
+
+func Lookup(year, number int) (Day, error) {
+	day, exists := registry[dayKey{year, number}]
+
+	if !exists {
+		return Day{}, fmt.Errorf("day %d of %d has no solver", number, year)
+	}
+	return day, nil
+}
+
+// Part returns the part (1 or 2) of the day, ok is false when it isn't solved.
+func (d Day) Part(number int) (Part, bool) {
+	if number < 1 || number > len(d.Parts) {
+		return Part{}, false
+	}
+	part := d.Parts[number-1]
+	return part, part.Solve != nil || part.Stream != nil
+}
+
+// Dir is the directory of the day, relative to the repository root.
+func (d Day) Dir() string {
+	return filepath.Join(strconv.Itoa(d.Year), fmt.Sprintf("day%02d", d.Number))
+}
+
+// DefaultOptions returns the options of the day set to their default values.
+func (d Day) DefaultOptions() Options {
+	opts := make(Options, len(d.Options))
+
+	for _, option := range d.Options {
+		opts[option.Name] = option.Default
+	}
+	return opts
+}
+
+// Option returns the declaration of the option of the day named name.
+func (d Day) Option(name string) (Option, bool) {
+	for _, option := range d.Options {
+		if option.Name == name {
+			return option, true
+		}
+	}
+	return Option{}, false
+}
+
+// ParseOptions returns the default options overridden by the given values,
+// every value must be an integer option declared by the day, within its
+// bounds.
+func (d Day) ParseOptions(values map[string]string) (Options, error) {
+	opts := d.DefaultOptions()
+
+	for name, value := range values {
+		option, declared := d.Option(name)
+		if !declared {
+			return nil, fmt.Errorf("day %d has no option named '%s'", d.Number, name)
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("option '%s' must be an integer: %v", name, err)
+		}
+		if err := option.Check(parsed); err != nil {
+			return nil, err
+		}
+		opts[name] = parsed
+	}
+	return opts, nil
+}
+
+// IntArgs parses every argument of a command as an integer, failing when
+// there aren't exactly n of them.
+func IntArgs(args []string, n int) ([]int, error) {
+	if len(args) != n {
+		return nil, fmt.Errorf("expected %d arguments, got %d", n, len(args))
+	}
+	values := make([]int, n)
+
+	for index, arg := range args {
+		value, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d isn't a number: %v", index+1, err)
+		}
+		values[index] = value
+	}
+	return values, nil
+}
+
+// Get returns the value of the option, 0 if it isn't set.
+func (o Options) Get(name string) int {
+	return o[name]
+}
@@ -0,0 +1,85 @@
+package puzzle
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+)
+
+type Result struct {
+	Year      int
+	Day, Part int
+	Answer    string
+	Duration  time.Duration
+	Err       error
+}
+
+// Run solves one part of the day and measures how long it took. Solvers
+// panicking on a malformed input are reported as errors.
+func (d Day) Run(part int, input []byte, opts Options) Result {
+	return d.run(part, func(p Part) (any, error) {
+		if p.Solve == nil {
+			return p.Stream(bytes.NewReader(input), opts)
+		}
+		return p.Solve(Normalize(input), opts)
+	})
+}
+
+// RunStream is Run reading the input from a reader, streaming parts never
+// hold it whole while the others load it first. The duration includes the
+// reading.
+func (d Day) RunStream(part int, input io.Reader, opts Options) Result {
+	return d.run(part, func(p Part) (any, error) {
+		if p.Stream != nil {
+			return p.Stream(input, opts)
+		}
+		raw, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		return p.Solve(Normalize(raw), opts)
+	})
+}
+
+func (d Day) run(part int, solve func(p Part) (any, error)) (result Result) {
+	result = Result{Year: d.Year, Day: d.Number, Part: part}
+	p, ok := d.Part(part)
+
+	if !ok {
+		result.Err = fmt.Errorf("day %d part %d has no solver", d.Number, part)
+		return result
+	}
+	start := time.Now()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result.Duration = time.Since(start)
+			result.Err = fmt.Errorf("solver panicked: %v", recovered)
+		}
+	}()
+	answer, err := solve(p)
+	result.Duration = time.Since(start)
+	slog.Debug("part solved", "day", d.Number, "part", part, "duration", result.Duration, "err", err)
+	if err != nil {
+		result.Err = err
+	} else {
+		result.Answer = fmt.Sprint(answer)
+	}
+	return result
+}
+
+// Render draws one part of the day, panics are reported as errors too.
+func (d Day) Render(part int, input []byte, opts Options) (rows []string, err error) {
+	p, ok := d.Part(part)
+
+	if !ok || p.Render == nil {
+		return nil, fmt.Errorf("day %d part %d has no renderer", d.Number, part)
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("renderer panicked: %v", recovered)
+		}
+	}()
+	return p.Render(Normalize(input), opts)
+}
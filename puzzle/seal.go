@@ -0,0 +1,141 @@
+package puzzle
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+const (
+	// Environment variable holding the hex encoded AES-256 key of the inputs
+	KeyEnv = "AOC_INPUT_KEY"
+	// Sealed inputs sit next to where their plaintext would be
+	SealedSuffix = ".sealed"
+
+	sealMagic = "AOCSEAL1"
+)
+
+// ErrNoKey is returned when a sealed input is read without a key.
+var ErrNoKey = errors.New("the input is sealed and " + KeyEnv + " isn't set")
+
+// InputKey returns the key of the environment, ErrNoKey when there is none.
+func InputKey() ([]byte, error) {
+	encoded := strings.TrimSpace(os.Getenv(KeyEnv))
+	if encoded == "" {
+		return nil, ErrNoKey
+	}
+	key, err := hex.DecodeString(encoded)
+	if err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("%s must hold 32 hex encoded bytes", KeyEnv)
+	}
+	return key, nil
+}
+
+// NewInputKey returns a random key, hex encoded as InputKey expects it.
+func NewInputKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(key), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Seal encrypts an input with AES-GCM, the nonce is stored after the magic
+// header.
+func Seal(plaintext, key []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	sealed := append([]byte(sealMagic), nonce...)
+	return gcm.Seal(sealed, nonce, plaintext, nil), nil
+}
+
+// Unseal decrypts an input sealed by Seal.
+func Unseal(sealed, key []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(sealed, []byte(sealMagic)) || len(sealed) < len(sealMagic)+gcm.NonceSize() {
+		return nil, fmt.Errorf("not a sealed input")
+	}
+	sealed = sealed[len(sealMagic):]
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't unseal the input, wrong key? %v", err)
+	}
+	return plaintext, nil
+}
+
+// OpenInput opens an input file, or decrypts its sealed version in memory
+// when the plaintext isn't there.
+func OpenInput(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if !errors.Is(err, os.ErrNotExist) {
+		return file, err
+	}
+	sealed, sealedErr := os.ReadFile(path + SealedSuffix)
+	if sealedErr != nil {
+		// The plaintext is the expected file
+		return nil, err
+	}
+	return openSealed(sealed)
+}
+
+// OpenInput for the files of a file system, the copies of the day files
+// embedded in the binary
+func openInputFS(files fs.FS, name string) (io.ReadCloser, error) {
+	file, err := files.Open(name)
+	if !errors.Is(err, fs.ErrNotExist) {
+		return file, err
+	}
+	sealed, sealedErr := fs.ReadFile(files, name+SealedSuffix)
+	if sealedErr != nil {
+		return nil, err
+	}
+	return openSealed(sealed)
+}
+
+func openSealed(sealed []byte) (io.ReadCloser, error) {
+	key, err := InputKey()
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := Unseal(sealed, key)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(plaintext)), nil
+}
+
+// InputFile returns the file holding the input of path: path itself, or its
+// sealed version when only that one exists.
+func InputFile(path string) string {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if _, err := os.Stat(path + SealedSuffix); err == nil {
+			return path + SealedSuffix
+		}
+	}
+	return path
+}
@@ -0,0 +1,85 @@
+package puzzle
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSealRoundTrip(t *testing.T) {
+	encoded, err := NewInputKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, _ := hex.DecodeString(encoded)
+	input := []byte("467..114..\n...*......\n")
+
+	sealed, err := Seal(input, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(sealed, input) {
+		t.Fatal("the sealed input holds its plaintext")
+	}
+	unsealed, err := Unseal(sealed, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(unsealed, input) {
+		t.Fatalf("unsealed %q, expected %q", unsealed, input)
+	}
+
+	otherKey := bytes.Repeat([]byte{1}, 32)
+	if _, err := Unseal(sealed, otherKey); err == nil {
+		t.Fatal("unsealed with the wrong key")
+	}
+	sealed[len(sealed)-1] ^= 1
+	if _, err := Unseal(sealed, key); err == nil {
+		t.Fatal("unsealed a tampered input")
+	}
+}
+
+func TestReadSealedInput(t *testing.T) {
+	encoded, err := NewInputKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, _ := hex.DecodeString(encoded)
+	path := filepath.Join(t.TempDir(), "input.txt")
+	sealed, err := Seal([]byte("1abc2\r\npqr3stu8vwx\r\n"), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path+SealedSuffix, sealed, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(KeyEnv, "")
+	if _, err := ReadInput(path); !errors.Is(err, ErrNoKey) {
+		t.Fatalf("read without a key: %v", err)
+	}
+
+	t.Setenv(KeyEnv, encoded)
+	input, err := ReadInput(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(input) != "1abc2\npqr3stu8vwx" {
+		t.Fatalf("read %q", input)
+	}
+	reader, err := OpenInput(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	if raw, _ := io.ReadAll(reader); string(raw) != "1abc2\r\npqr3stu8vwx\r\n" {
+		t.Fatalf("opened %q", raw)
+	}
+	if InputFile(path) != path+SealedSuffix {
+		t.Fatalf("the sealed file isn't the input file of %s", path)
+	}
+}
@@ -0,0 +1,79 @@
+package puzzle
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ParagraphKind int
+
+const (
+	// "--- Day 5: ... ---" and "--- Part Two ---"
+	HeadingParagraph ParagraphKind = iota
+	ProseParagraph
+	// Preformatted block, see ExtractExamples
+	ExampleParagraph
+)
+
+// Paragraph of a puzzle statement.
+type Paragraph struct {
+	Kind ParagraphKind
+	// 1 until the "--- Part Two ---" heading, 2 from it
+	Part int
+	// 1-based line the paragraph starts at in instructions.txt
+	Line int
+	Text string
+	// Index of an example paragraph, the one aoc run -example takes
+	Block int
+}
+
+// ParseStatement splits instructions into headings, prose lines and example
+// blocks.
+func ParseStatement(instructions []byte) []Paragraph {
+	paragraphs := make([]Paragraph, 0)
+	current, currentLine := make([]string, 0), 0
+	part, blocks := 1, 0
+
+	flush := func() {
+		for len(current) > 0 && strings.TrimSpace(current[len(current)-1]) == "" {
+			current = current[:len(current)-1]
+		}
+		if len(current) > 0 {
+			paragraphs = append(paragraphs, Paragraph{Kind: ExampleParagraph, Part: part, Line: currentLine, Text: strings.Join(current, "\n"), Block: blocks})
+			blocks++
+		}
+		current = current[:0]
+	}
+
+	for index, line := range strings.Split(string(Normalize(instructions)), "\n") {
+		switch {
+		case strings.HasPrefix(line, "---"):
+			flush()
+			if strings.Contains(line, "Part Two") {
+				part = 2
+			}
+			paragraphs = append(paragraphs, Paragraph{Kind: HeadingParagraph, Part: part, Line: index + 1, Text: strings.TrimSpace(line)})
+		case isProse(line):
+			flush()
+			paragraphs = append(paragraphs, Paragraph{Kind: ProseParagraph, Part: part, Line: index + 1, Text: strings.TrimSpace(line)})
+		case strings.TrimSpace(line) == "" && len(current) == 0:
+			continue
+		default:
+			if len(current) == 0 {
+				currentLine = index + 1
+			}
+			current = append(current, strings.TrimRight(line, " "))
+		}
+	}
+	flush()
+	return paragraphs
+}
+
+// Statement returns the paragraphs of the day's instructions.txt.
+func (d Day) Statement() ([]Paragraph, error) {
+	file, err := d.readFile(instructionsFilename)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open instructions file\n%v", err)
+	}
+	return ParseStatement(file), nil
+}
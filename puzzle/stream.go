@@ -0,0 +1,99 @@
+package puzzle
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// Longest record a Stream accepts, generated inputs can have long lines
+const maxRecordSize = 64 << 20
+
+// Stream iterates over the records of an input (its lines, or the values of
+// a separated list) without loading it whole, it is used like a
+// bufio.Scanner:
+//
+//	lines := puzzle.Lines(input)
+//	for lines.Next() {
+//		... lines.Text()
+//	}
+//	return lines.Err()
+//
+// Empty records are skipped, as Normalize drops the trailing newlines.
+type Stream struct {
+	scanner *bufio.Scanner
+	// 1-based number of the current record, skipped ones included
+	record int
+}
+
+func newStream(input io.Reader, split bufio.SplitFunc) *Stream {
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+	scanner.Split(split)
+	return &Stream{scanner: scanner}
+}
+
+// Lines streams the lines of the input, "\r\n" line endings included.
+func Lines(input io.Reader) *Stream {
+	return newStream(input, bufio.ScanLines)
+}
+
+// Values streams the values of an input separated by sep, newlines are
+// ignored (day15 steps are a single comma separated line).
+func Values(input io.Reader, sep byte) *Stream {
+	return newStream(input, func(data []byte, atEOF bool) (int, []byte, error) {
+		if index := bytes.IndexByte(data, sep); index >= 0 {
+			return index + 1, dropNewlines(data[:index]), nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), dropNewlines(data), nil
+		}
+		return 0, nil, nil
+	})
+}
+
+func dropNewlines(token []byte) []byte {
+	if !bytes.ContainsAny(token, "\r\n") {
+		return token
+	}
+	cleaned := make([]byte, 0, len(token))
+	for _, b := range token {
+		if b != '\r' && b != '\n' {
+			cleaned = append(cleaned, b)
+		}
+	}
+	return cleaned
+}
+
+// Next advances to the next non empty record, false at the end of the input
+// or on a read error.
+func (s *Stream) Next() bool {
+	for s.scanner.Scan() {
+		s.record++
+		if len(s.scanner.Bytes()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Text returns the current record.
+func (s *Stream) Text() string {
+	return s.scanner.Text()
+}
+
+// Bytes returns the current record, it is only valid until the next call to
+// Next.
+func (s *Stream) Bytes() []byte {
+	return s.scanner.Bytes()
+}
+
+// Fail locates an error about the current record.
+func (s *Stream) Fail(err error) error {
+	return &ParseError{Line: s.record, Text: s.Text(), Err: err}
+}
+
+// Err returns the error that stopped the stream, nil at the end of the input.
+func (s *Stream) Err() error {
+	return s.scanner.Err()
+}
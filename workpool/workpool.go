@@ -0,0 +1,88 @@
+// Package workpool spreads independent items over a bounded amount of
+// goroutines shared by every solver of the process.
+package workpool
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// Tokens of the helper goroutines, the goroutine calling Map always works
+	// too so a pool of n workers holds n-1 tokens
+	tokens = make(chan struct{}, runtime.NumCPU()-1)
+)
+
+// SetSize sets the amount of workers of the pool, it isn't meant to be called
+// while items are being processed.
+func SetSize(workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	tokens = make(chan struct{}, workers-1)
+}
+
+func Size() int {
+	return cap(tokens) + 1
+}
+
+// Map calls fn on every item and returns the results in the items order.
+func Map[T, R any](items []T, fn func(T) R) []R {
+	return MapLocal(items, func() struct{} { return struct{}{} }, func(_ struct{}, item T) R {
+		return fn(item)
+	})
+}
+
+// MapLocal is Map for solvers reusing a scratch model between items: every
+// worker gets its own local value from newLocal and passes it to fn.
+//
+// Helpers are only started while tokens are available, otherwise the caller
+// processes the items alone, so nested calls (a solver using the pool while
+// the runner spreads the parts over it) can't deadlock.
+func MapLocal[L, T, R any](items []T, newLocal func() L, fn func(L, T) R) []R {
+	results := make([]R, len(items))
+	pool := tokens
+	next := atomic.Int64{}
+	var wg sync.WaitGroup
+	var panicked atomic.Value
+
+	work := func() {
+		// Panics stop every worker and are forwarded to the caller, so
+		// puzzle.Run can report them
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				panicked.CompareAndSwap(nil, fmt.Sprint(recovered))
+				next.Store(int64(len(items)))
+			}
+		}()
+		local := newLocal()
+
+		for index := int(next.Add(1) - 1); index < len(items); index = int(next.Add(1) - 1) {
+			results[index] = fn(local, items[index])
+		}
+	}
+
+spawn:
+	for helpers := 0; helpers < len(items)-1; helpers++ {
+		select {
+		case pool <- struct{}{}:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-pool }()
+				work()
+			}()
+		default:
+			break spawn
+		}
+	}
+	work()
+	wg.Wait()
+
+	if recovered := panicked.Load(); recovered != nil {
+		panic(recovered)
+	}
+	return results
+}